@@ -0,0 +1,222 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config keys of the api sub-system.
+const (
+	APIRequestsMaxKey           = "requests_max"
+	APIRequestsDeadlineKey      = "requests_deadline"
+	APIReplicationMaxWorkersKey = "replication_max_workers"
+	APIReplicationPriorityKey   = "replication_priority"
+)
+
+// Bounds accepted by the server for the number of replication workers.
+const (
+	ReplicationWorkersMin = 1
+	ReplicationWorkersMax = 500
+)
+
+func validateReplicationWorkers(n int) error {
+	if n < ReplicationWorkersMin || n > ReplicationWorkersMax {
+		return fmt.Errorf("replication workers must be between %d and %d, got %d",
+			ReplicationWorkersMin, ReplicationWorkersMax, n)
+	}
+	return nil
+}
+
+// GetReplicationWorkers - returns the maximum number of replication workers
+// configured on the server.
+func (adm *AdminClient) GetReplicationWorkers(ctx context.Context) (int, error) {
+	cfg, err := adm.getSubsysConfig(ctx, APISubSys)
+	if err != nil {
+		return 0, err
+	}
+	return lookupInt(cfg, APIReplicationMaxWorkersKey)
+}
+
+// SetReplicationWorkers - sets the maximum number of replication workers.
+func (adm *AdminClient) SetReplicationWorkers(ctx context.Context, n int) error {
+	if err := validateReplicationWorkers(n); err != nil {
+		return err
+	}
+	return adm.setSubsysConfig(ctx, APISubSys, APIReplicationMaxWorkersKey+KvSeparator+strconv.Itoa(n))
+}
+
+// ReplQueueStats holds the replication queue and worker stats of a site,
+// along with the configured worker limits, for tuning the workers.
+type ReplQueueStats struct {
+	// ActiveWorkers is the number of busy replication workers.
+	ActiveWorkers WorkerStat `json:"activeWorkers"`
+	// Queued is the number of objects and bytes in the replication queue.
+	Queued InQueueMetric `json:"queued"`
+	// Priority is the configured replication priority, e.g. "auto".
+	Priority string `json:"priority,omitempty"`
+	// MaxWorkers is the configured maximum number of workers, 0 if unset.
+	MaxWorkers int `json:"maxWorkers,omitempty"`
+	// Utilization is the percentage of MaxWorkers currently busy,
+	// 0 if MaxWorkers is unset.
+	Utilization float64 `json:"utilization"`
+	// AvgQueueWait is the estimated average time an object waits in the
+	// queue, 0 if nothing was replicated yet.
+	AvgQueueWait time.Duration `json:"avgQueueWait"`
+}
+
+// ReplicationQueueStats - returns the replication queue and worker stats of
+// this site along with the configured worker limits. The stats are taken
+// from the site replication metrics, so only replication to peer sites is
+// covered. The server does not measure queue wait times, AvgQueueWait is
+// estimated from the average queue length and the replication rate since
+// the server started.
+func (adm *AdminClient) ReplicationQueueStats(ctx context.Context) (ReplQueueStats, error) {
+	info, err := adm.SRStatusInfo(ctx, SRStatusOptions{Metrics: true})
+	if err != nil {
+		return ReplQueueStats{}, err
+	}
+	cfg, err := adm.getSubsysConfig(ctx, APISubSys)
+	if err != nil {
+		return ReplQueueStats{}, err
+	}
+	return replQueueStats(info.Metrics, cfg)
+}
+
+func replQueueStats(m SRMetricsSummary, cfg SubsysConfig) (ReplQueueStats, error) {
+	st := ReplQueueStats{
+		ActiveWorkers: m.ActiveWorkers,
+		Queued:        m.Queued,
+	}
+	st.Priority, _ = cfg.Lookup(APIReplicationPriorityKey)
+	maxWorkers, err := lookupInt(cfg, APIReplicationMaxWorkersKey)
+	if err != nil {
+		return ReplQueueStats{}, err
+	}
+	st.MaxWorkers = maxWorkers
+	if maxWorkers > 0 {
+		st.Utilization = float64(m.ActiveWorkers.Curr) / float64(maxWorkers) * 100
+	}
+
+	// Little's law: the average wait is the average queue length
+	// divided by the rate at which objects leave the queue.
+	var replicated int64
+	for _, peer := range m.Metrics {
+		replicated += peer.ReplicatedCount
+	}
+	if replicated > 0 && m.Uptime > 0 {
+		rate := float64(replicated) / float64(m.Uptime)
+		st.AvgQueueWait = time.Duration(m.Queued.Avg.Count / rate * float64(time.Second))
+	}
+	return st, nil
+}
+
+// GetAPIRequestsMax - returns the maximum number of concurrent S3 API requests
+// allowed on the server. Zero means the limit is computed automatically from
+// the available memory.
+func (adm *AdminClient) GetAPIRequestsMax(ctx context.Context) (int, error) {
+	cfg, err := adm.getSubsysConfig(ctx, APISubSys)
+	if err != nil {
+		return 0, err
+	}
+	return lookupInt(cfg, APIRequestsMaxKey)
+}
+
+// SetAPIRequestsMax - sets the maximum number of concurrent S3 API requests.
+// Use zero to let the server compute the limit automatically.
+func (adm *AdminClient) SetAPIRequestsMax(ctx context.Context, n int) error {
+	if n < 0 {
+		return errors.New("api requests_max must not be negative")
+	}
+	return adm.setSubsysConfig(ctx, APISubSys, APIRequestsMaxKey+KvSeparator+strconv.Itoa(n))
+}
+
+// GetAPIRequestsDeadline - returns how long a request waits for a free slot
+// when the maximum number of concurrent requests is reached.
+func (adm *AdminClient) GetAPIRequestsDeadline(ctx context.Context) (time.Duration, error) {
+	cfg, err := adm.getSubsysConfig(ctx, APISubSys)
+	if err != nil {
+		return 0, err
+	}
+	return lookupDuration(cfg, APIRequestsDeadlineKey)
+}
+
+// SetAPIRequestsDeadline - sets how long a request waits for a free slot
+// when the maximum number of concurrent requests is reached.
+func (adm *AdminClient) SetAPIRequestsDeadline(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return errors.New("api requests_deadline must be greater than zero")
+	}
+	return adm.setSubsysConfig(ctx, APISubSys, APIRequestsDeadlineKey+KvSeparator+d.String())
+}
+
+// APICorsAllowOriginKey is the config key of the api sub-system listing
+// the allowed CORS origins.
+const APICorsAllowOriginKey = "cors_allow_origin"
+
+// CORSConfig holds the server wide CORS settings of the S3 API.
+type CORSConfig struct {
+	AllowOrigins []string `json:"allowOrigins"`
+}
+
+// Validate returns an error if the CORS configuration is invalid.
+func (c CORSConfig) Validate() error {
+	if len(c.AllowOrigins) == 0 {
+		return errors.New("at least one allowed CORS origin is required")
+	}
+	for _, v := range c.AllowOrigins {
+		if v == "" || HasSpace(v) || strings.ContainsAny(v, `,"`) {
+			return fmt.Errorf("invalid %s value %q", APICorsAllowOriginKey, v)
+		}
+	}
+	return nil
+}
+
+func (c CORSConfig) kvs() []string {
+	return []string{APICorsAllowOriginKey + KvSeparator + KvDoubleQuote + strings.Join(c.AllowOrigins, ",") + KvDoubleQuote}
+}
+
+func parseCORSConfig(cfg SubsysConfig) CORSConfig {
+	return CORSConfig{
+		AllowOrigins: lookupList(cfg, APICorsAllowOriginKey),
+	}
+}
+
+// GetCORSConfig - returns the server wide CORS settings of the S3 API.
+func (adm *AdminClient) GetCORSConfig(ctx context.Context) (CORSConfig, error) {
+	cfg, err := adm.getSubsysConfig(ctx, APISubSys)
+	if err != nil {
+		return CORSConfig{}, err
+	}
+	return parseCORSConfig(cfg), nil
+}
+
+// SetCORSConfig - sets the server wide CORS settings of the S3 API.
+func (adm *AdminClient) SetCORSConfig(ctx context.Context, c CORSConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	return adm.setSubsysConfig(ctx, APISubSys, c.kvs()...)
+}
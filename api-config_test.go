@@ -0,0 +1,213 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplicationWorkersRoundTrip(t *testing.T) {
+	for _, want := range []int{ReplicationWorkersMin, 100, ReplicationWorkersMax} {
+		if err := validateReplicationWorkers(want); err != nil {
+			t.Fatalf("%d: unexpected validation error: %v", want, err)
+		}
+		cfgs, err := ParseServerConfigOutput(APISubSys + " requests_max=0 " + APIReplicationMaxWorkersKey + "=" + strconv.Itoa(want))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := lookupInt(cfgs[0], APIReplicationMaxWorkersKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("expected %d, got %d", want, got)
+		}
+	}
+}
+
+func TestReplicationWorkersValidate(t *testing.T) {
+	for _, n := range []int{-1, 0, ReplicationWorkersMax + 1} {
+		if err := validateReplicationWorkers(n); err == nil {
+			t.Errorf("%d: expected validation error", n)
+		}
+	}
+}
+
+func TestAPIRequestsRoundTrip(t *testing.T) {
+	tests := []struct {
+		max      int
+		deadline time.Duration
+	}{
+		{max: 0, deadline: 10 * time.Second},
+		{max: 1600, deadline: time.Minute},
+		{max: 10000, deadline: 1500 * time.Millisecond},
+	}
+	for _, test := range tests {
+		line := APISubSys + " " + APIRequestsMaxKey + "=" + strconv.Itoa(test.max) +
+			" " + APIRequestsDeadlineKey + "=" + test.deadline.String()
+		cfgs, err := ParseServerConfigOutput(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotMax, err := lookupInt(cfgs[0], APIRequestsMaxKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotMax != test.max {
+			t.Errorf("expected requests_max %d, got %d", test.max, gotMax)
+		}
+		gotDeadline, err := lookupDuration(cfgs[0], APIRequestsDeadlineKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotDeadline != test.deadline {
+			t.Errorf("expected requests_deadline %v, got %v", test.deadline, gotDeadline)
+		}
+	}
+}
+
+func TestAPIRequestsDeadlineInvalid(t *testing.T) {
+	cfgs, err := ParseServerConfigOutput(APISubSys + " " + APIRequestsDeadlineKey + "=10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lookupDuration(cfgs[0], APIRequestsDeadlineKey); err == nil {
+		t.Error("expected error for duration without unit")
+	}
+}
+
+func TestCORSConfigRoundTrip(t *testing.T) {
+	tests := []CORSConfig{
+		{AllowOrigins: []string{"*"}},
+		{AllowOrigins: []string{"https://example.com", "https://console.example.com"}},
+	}
+	for _, want := range tests {
+		if err := want.Validate(); err != nil {
+			t.Fatalf("%+v: unexpected validation error: %v", want, err)
+		}
+		cfgs, err := ParseServerConfigOutput(APISubSys + " requests_max=0 " + strings.Join(want.kvs(), " "))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := parseCORSConfig(cfgs[0])
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestCORSConfigValidate(t *testing.T) {
+	tests := []CORSConfig{
+		{},
+		{AllowOrigins: []string{""}},
+		{AllowOrigins: []string{"https://a.com,https://b.com"}},
+		{AllowOrigins: []string{"https://a.com b"}},
+		{AllowOrigins: []string{"https://a.com", `"`}},
+	}
+	for _, c := range tests {
+		if err := c.Validate(); err == nil {
+			t.Errorf("%+v: expected validation error", c)
+		}
+	}
+}
+
+func TestReplQueueStats(t *testing.T) {
+	cfgs, err := ParseServerConfigOutput(APISubSys + " requests_max=0 " + APIReplicationPriorityKey + "=auto " + APIReplicationMaxWorkersKey + "=200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := SRMetricsSummary{
+		ActiveWorkers: WorkerStat{Curr: 50, Avg: 40, Max: 120},
+		Queued: InQueueMetric{
+			Curr: QStat{Count: 30, Bytes: 3 << 20},
+			Avg:  QStat{Count: 20, Bytes: 2 << 20},
+		},
+		Metrics: map[string]SRMetric{
+			"dep-2": {ReplicatedCount: 6000},
+			"dep-3": {ReplicatedCount: 4000},
+		},
+		Uptime: 1000,
+	}
+
+	st, err := replQueueStats(m, cfgs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ReplQueueStats{
+		ActiveWorkers: m.ActiveWorkers,
+		Queued:        m.Queued,
+		Priority:      "auto",
+		MaxWorkers:    200,
+		Utilization:   25,
+		// 10 objects replicated per second with 20 queued on average.
+		AvgQueueWait: 2 * time.Second,
+	}
+	if !reflect.DeepEqual(st, want) {
+		t.Errorf("expected %+v, got %+v", want, st)
+	}
+
+	// Nothing replicated and no worker limit configured.
+	st, err = replQueueStats(SRMetricsSummary{Queued: m.Queued}, SubsysConfig{SubSystem: APISubSys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Utilization != 0 || st.AvgQueueWait != 0 || st.MaxWorkers != 0 {
+		t.Errorf("unexpected stats %+v", st)
+	}
+}
+
+func TestGetSetReplicationWorkers(t *testing.T) {
+	adm, received := newTestConfigKVClient(t, APISubSys, APISubSys+" requests_max=0 "+APIReplicationMaxWorkersKey+"=250")
+	n, err := adm.GetReplicationWorkers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 250 {
+		t.Errorf("expected 250 workers, got %d", n)
+	}
+	if err = adm.SetReplicationWorkers(context.Background(), 100); err != nil {
+		t.Fatal(err)
+	}
+	if want := APISubSys + " " + APIReplicationMaxWorkersKey + "=100"; *received != want {
+		t.Errorf("expected %q, got %q", want, *received)
+	}
+}
+
+func TestGetSetCORSConfig(t *testing.T) {
+	adm, received := newTestConfigKVClient(t, APISubSys, APISubSys+" requests_max=0 "+APICorsAllowOriginKey+`="https://a.com,https://b.com"`)
+	got, err := adm.GetCORSConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"https://a.com", "https://b.com"}; !reflect.DeepEqual(got.AllowOrigins, want) {
+		t.Errorf("expected %v, got %v", want, got.AllowOrigins)
+	}
+	if err = adm.SetCORSConfig(context.Background(), CORSConfig{AllowOrigins: []string{"*"}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := APISubSys + " " + APICorsAllowOriginKey + `="*"`; *received != want {
+		t.Errorf("expected %q, got %q", want, *received)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -61,6 +62,25 @@ func (e ErrorResponse) Error() string {
 	return e.Message
 }
 
+// Sentinel errors matched by ErrorResponse values using errors.Is.
+var (
+	// ErrPolicyNotFound is matched by errors returned by policy
+	// operations when the requested policy does not exist.
+	ErrPolicyNotFound = errors.New("policy not found")
+)
+
+// errorCodeSentinels maps server error codes to sentinel errors.
+var errorCodeSentinels = map[string]error{
+	"XMinioAdminNoSuchPolicy": ErrPolicyNotFound,
+}
+
+// Is reports whether e corresponds to the target sentinel error.
+// For example, errors.Is(err, ErrPolicyNotFound).
+func (e ErrorResponse) Is(target error) bool {
+	sentinel, ok := errorCodeSentinels[e.Code]
+	return ok && sentinel == target
+}
+
 const (
 	reportIssue = "Please report this issue at https://github.com/minio/minio/issues."
 )
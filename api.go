@@ -72,6 +72,9 @@ type AdminClient struct {
 	// Advanced functionality.
 	isTraceEnabled bool
 	traceOutput    io.Writer
+
+	// Cached ServerInfo responses.
+	serverInfoCache *serverInfoCache
 }
 
 // Global constants.
@@ -97,6 +100,11 @@ type Options struct {
 	Creds     *credentials.Credentials
 	Secure    bool
 	Transport http.RoundTripper
+
+	// ServerInfoCacheTTL makes ServerInfo return the result of a previous
+	// call with the same options for this long, 0 disables caching.
+	ServerInfoCacheTTL time.Duration
+
 	// Add future fields here
 }
 
@@ -158,6 +166,8 @@ func privateNew(endpoint string, opts *Options) (*AdminClient, error) {
 		Transport: tr,
 	}
 
+	clnt.serverInfoCache = &serverInfoCache{ttl: opts.ServerInfoCacheTTL}
+
 	// Add locked pseudo-random number generator.
 	clnt.random = rand.New(&lockedRandSource{src: rand.NewSource(time.Now().UTC().UnixNano())})
 
@@ -22,8 +22,11 @@ package madmin
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 )
 
@@ -91,3 +94,50 @@ func (adm *AdminClient) GetBucketBandwidth(ctx context.Context, buckets ...strin
 	}(ctx, ch, resp)
 	return ch
 }
+
+// MonitorBandwidth - streams the bandwidth measurements of the given replication
+// buckets, or of all of them if none are given, calling onSample for every bucket
+// of every report in bucket order. It returns nil when the server ends the
+// stream, or the first error returned by onSample.
+func (adm *AdminClient) MonitorBandwidth(ctx context.Context, buckets []string, onSample func(bucket string, bw BandwidthDetails) error) error {
+	queryValues := url.Values{}
+	if len(buckets) > 0 {
+		queryValues.Set("buckets", strings.Join(buckets, ","))
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath:     adminAPIPrefix + "/bandwidth",
+		queryValues: queryValues,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var report BucketBandwidthReport
+		if err = dec.Decode(&report); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		names := make([]string, 0, len(report.BucketStats))
+		for bucket := range report.BucketStats {
+			names = append(names, bucket)
+		}
+		sort.Strings(names)
+		for _, bucket := range names {
+			if err = onSample(bucket, report.BucketStats[bucket]); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMonitorBandwidth(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/bandwidth" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("buckets"); got != "photos,logs" {
+			t.Errorf("unexpected buckets %q", got)
+		}
+		w.Write([]byte(`{"bucketStats":{"photos":{"limitInBits":1048576,"currentBandwidth":512},"logs":{"limitInBits":2048,"currentBandwidth":1024}}}
+{"bucketStats":{"photos":{"limitInBits":1048576,"currentBandwidth":4096}}}
+`))
+	})
+
+	var got []string
+	err := adm.MonitorBandwidth(context.Background(), []string{"photos", "logs"}, func(bucket string, bw BandwidthDetails) error {
+		got = append(got, fmt.Sprintf("%s:%d/%v", bucket, bw.LimitInBytesPerSecond, bw.CurrentBandwidthInBytesPerSecond))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"logs:2048/1024", "photos:1048576/512", "photos:1048576/4096"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected samples %q, got %q", want, got)
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = adm.MonitorBandwidth(context.Background(), []string{"photos", "logs"}, func(string, BandwidthDetails) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("expected monitoring to stop after the first sample, got %v after %d calls", err, calls)
+	}
+}
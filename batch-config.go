@@ -0,0 +1,95 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Config keys of the batch sub-system.
+const (
+	BatchReplicationWorkersWaitKey = "replication_workers_wait"
+	BatchKeyRotationWorkersWaitKey = "keyrotation_workers_wait"
+	BatchExpirationWorkersWaitKey  = "expiration_workers_wait"
+)
+
+// BatchJobConfig holds the server wide settings of batch jobs.
+// Retry attempts and their delay are set per job in the job definition.
+type BatchJobConfig struct {
+	// ReplicationWorkersWait is the time each replication worker
+	// waits between processing two objects.
+	ReplicationWorkersWait time.Duration `json:"replicationWorkersWait"`
+
+	// KeyRotationWorkersWait is the time each key rotation worker
+	// waits between processing two objects.
+	KeyRotationWorkersWait time.Duration `json:"keyRotationWorkersWait"`
+
+	// ExpirationWorkersWait is the time each expiration worker
+	// waits between processing two objects.
+	ExpirationWorkersWait time.Duration `json:"expirationWorkersWait"`
+}
+
+// Validate returns an error if the batch job configuration is invalid.
+func (c BatchJobConfig) Validate() error {
+	if c.ReplicationWorkersWait < 0 || c.KeyRotationWorkersWait < 0 || c.ExpirationWorkersWait < 0 {
+		return errors.New("batch workers wait must not be negative")
+	}
+	return nil
+}
+
+func (c BatchJobConfig) kvs() []string {
+	return []string{
+		BatchReplicationWorkersWaitKey + KvSeparator + c.ReplicationWorkersWait.String(),
+		BatchKeyRotationWorkersWaitKey + KvSeparator + c.KeyRotationWorkersWait.String(),
+		BatchExpirationWorkersWaitKey + KvSeparator + c.ExpirationWorkersWait.String(),
+	}
+}
+
+func parseBatchJobConfig(cfg SubsysConfig) (c BatchJobConfig, err error) {
+	if c.ReplicationWorkersWait, err = lookupDuration(cfg, BatchReplicationWorkersWaitKey); err != nil {
+		return c, err
+	}
+	if c.KeyRotationWorkersWait, err = lookupDuration(cfg, BatchKeyRotationWorkersWaitKey); err != nil {
+		return c, err
+	}
+	if c.ExpirationWorkersWait, err = lookupDuration(cfg, BatchExpirationWorkersWaitKey); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// GetBatchJobConfig - returns the server wide settings of batch jobs.
+func (adm *AdminClient) GetBatchJobConfig(ctx context.Context) (BatchJobConfig, error) {
+	cfg, err := adm.getSubsysConfig(ctx, BatchSubSys)
+	if err != nil {
+		return BatchJobConfig{}, err
+	}
+	return parseBatchJobConfig(cfg)
+}
+
+// SetBatchJobConfig - sets the server wide settings of batch jobs.
+func (adm *AdminClient) SetBatchJobConfig(ctx context.Context, c BatchJobConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	return adm.setSubsysConfig(ctx, BatchSubSys, c.kvs()...)
+}
@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBatchJobConfigRoundTrip(t *testing.T) {
+	tests := []BatchJobConfig{
+		{},
+		{ReplicationWorkersWait: 0, KeyRotationWorkersWait: 100 * time.Millisecond},
+		{ReplicationWorkersWait: 10 * time.Millisecond, KeyRotationWorkersWait: time.Second, ExpirationWorkersWait: 1500 * time.Millisecond},
+	}
+	for _, want := range tests {
+		if err := want.Validate(); err != nil {
+			t.Fatalf("%+v: unexpected validation error: %v", want, err)
+		}
+		cfgs, err := ParseServerConfigOutput(BatchSubSys + " " + strings.Join(want.kvs(), " "))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parseBatchJobConfig(cfgs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestBatchJobConfigValidate(t *testing.T) {
+	c := BatchJobConfig{ExpirationWorkersWait: -time.Second}
+	if err := c.Validate(); err == nil {
+		t.Errorf("%+v: expected validation error", c)
+	}
+}
+
+func TestGetSetBatchJobConfig(t *testing.T) {
+	adm, received := newTestConfigKVClient(t, BatchSubSys, BatchSubSys+" replication_workers_wait=0ms keyrotation_workers_wait=100ms expiration_workers_wait=1s")
+	got, err := adm.GetBatchJobConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BatchJobConfig{KeyRotationWorkersWait: 100 * time.Millisecond, ExpirationWorkersWait: time.Second}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if err = adm.SetBatchJobConfig(context.Background(), BatchJobConfig{ReplicationWorkersWait: 10 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	if want := BatchSubSys + " replication_workers_wait=10ms keyrotation_workers_wait=0s expiration_workers_wait=0s"; *received != want {
+		t.Errorf("expected %q, got %q", want, *received)
+	}
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"fmt"
+)
+
+// Config keys of the cache sub-system.
+const (
+	CacheQuotaKey         = "quota"
+	CacheWatermarkLowKey  = "watermark_low"
+	CacheWatermarkHighKey = "watermark_high"
+	CacheRangeKey         = "range"
+)
+
+// CacheConfig holds the drive cache settings of the server.
+// Quota and watermarks are percentages, zero keeps the server default.
+type CacheConfig struct {
+	// Quota is the percentage of each cache drive that may be used.
+	Quota int `json:"quota"`
+
+	// WatermarkLow is the usage percentage of the quota
+	// at which cache eviction stops.
+	WatermarkLow int `json:"watermarkLow"`
+
+	// WatermarkHigh is the usage percentage of the quota
+	// at which cache eviction starts.
+	WatermarkHigh int `json:"watermarkHigh"`
+
+	// Range enables caching of range GET requests.
+	Range bool `json:"range"`
+}
+
+// Validate returns an error if the cache configuration is invalid.
+func (c CacheConfig) Validate() error {
+	for _, p := range []struct {
+		key   string
+		value int
+	}{
+		{CacheQuotaKey, c.Quota},
+		{CacheWatermarkLowKey, c.WatermarkLow},
+		{CacheWatermarkHighKey, c.WatermarkHigh},
+	} {
+		if p.value < 0 || p.value > 100 {
+			return fmt.Errorf("cache %s must be a percentage between 0 and 100, got %d", p.key, p.value)
+		}
+	}
+	if c.WatermarkLow > c.WatermarkHigh {
+		return fmt.Errorf("cache %s %d exceeds %s %d",
+			CacheWatermarkLowKey, c.WatermarkLow, CacheWatermarkHighKey, c.WatermarkHigh)
+	}
+	return nil
+}
+
+func (c CacheConfig) kvs() []string {
+	return []string{
+		CacheQuotaKey + KvSeparator + formatInt(c.Quota),
+		CacheWatermarkLowKey + KvSeparator + formatInt(c.WatermarkLow),
+		CacheWatermarkHighKey + KvSeparator + formatInt(c.WatermarkHigh),
+		CacheRangeKey + KvSeparator + formatBool(c.Range),
+	}
+}
+
+func parseCacheConfig(cfg SubsysConfig) (c CacheConfig, err error) {
+	if c.Quota, err = lookupInt(cfg, CacheQuotaKey); err != nil {
+		return c, err
+	}
+	if c.WatermarkLow, err = lookupInt(cfg, CacheWatermarkLowKey); err != nil {
+		return c, err
+	}
+	if c.WatermarkHigh, err = lookupInt(cfg, CacheWatermarkHighKey); err != nil {
+		return c, err
+	}
+	if c.Range, err = lookupBool(cfg, CacheRangeKey); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// GetCacheConfig - returns the drive cache settings of the server.
+func (adm *AdminClient) GetCacheConfig(ctx context.Context) (CacheConfig, error) {
+	cfg, err := adm.getSubsysConfig(ctx, CacheSubSys)
+	if err != nil {
+		return CacheConfig{}, err
+	}
+	return parseCacheConfig(cfg)
+}
+
+// SetCacheConfig - sets the drive cache settings of the server.
+func (adm *AdminClient) SetCacheConfig(ctx context.Context, c CacheConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	return adm.setSubsysConfig(ctx, CacheSubSys, c.kvs()...)
+}
@@ -0,0 +1,82 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCacheConfigRoundTrip(t *testing.T) {
+	tests := []CacheConfig{
+		{},
+		{Quota: 80, WatermarkLow: 70, WatermarkHigh: 80, Range: true},
+		{Quota: 100, WatermarkLow: 90, WatermarkHigh: 90},
+	}
+	for _, want := range tests {
+		if err := want.Validate(); err != nil {
+			t.Fatalf("%+v: unexpected validation error: %v", want, err)
+		}
+		cfgs, err := ParseServerConfigOutput(CacheSubSys + " " + strings.Join(want.kvs(), " "))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parseCacheConfig(cfgs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestCacheConfigValidate(t *testing.T) {
+	tests := []CacheConfig{
+		{Quota: 101},
+		{Quota: -1},
+		{WatermarkLow: 50, WatermarkHigh: 101},
+		{WatermarkLow: 90, WatermarkHigh: 80},
+	}
+	for _, c := range tests {
+		if err := c.Validate(); err == nil {
+			t.Errorf("%+v: expected validation error", c)
+		}
+	}
+}
+
+func TestGetSetCacheConfig(t *testing.T) {
+	adm, received := newTestConfigKVClient(t, CacheSubSys, CacheSubSys+" quota=80 watermark_low=70 watermark_high=80 range=on")
+	got, err := adm.GetCacheConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := CacheConfig{Quota: 80, WatermarkLow: 70, WatermarkHigh: 80, Range: true}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if err = adm.SetCacheConfig(context.Background(), CacheConfig{Quota: 90, WatermarkHigh: 85}); err != nil {
+		t.Fatal(err)
+	}
+	if want := CacheSubSys + " quota=90 watermark_low= watermark_high=85 range=off"; *received != want {
+		t.Errorf("expected %q, got %q", want, *received)
+	}
+}
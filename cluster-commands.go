@@ -22,9 +22,12 @@ package madmin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"time"
 
@@ -749,6 +752,70 @@ type SRStatusInfo struct {
 	APIVersion     string `json:"apiVersion,omitempty"`
 }
 
+// SREntityDiff lists the entities, such as buckets or users, whose
+// replication status changed between two SRStatusInfo snapshots.
+type SREntityDiff struct {
+	Added   []string `json:"added,omitempty"`   // Entities with a replication status only in the newer snapshot
+	Removed []string `json:"removed,omitempty"` // Entities with a replication status only in the older snapshot
+	Changed []string `json:"changed,omitempty"` // Entities whose replication status changed on any site
+}
+
+// Empty returns true if no entity changed.
+func (d SREntityDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// SRStatusDiff holds the changes in replication status between two SRStatusInfo snapshots.
+type SRStatusDiff struct {
+	Buckets        SREntityDiff `json:"buckets"`
+	Policies       SREntityDiff `json:"policies"`
+	Users          SREntityDiff `json:"users"`
+	Groups         SREntityDiff `json:"groups"`
+	ILMExpiryRules SREntityDiff `json:"ilmExpiryRules"`
+}
+
+// Empty returns true if no entity changed.
+func (d SRStatusDiff) Empty() bool {
+	return d.Buckets.Empty() && d.Policies.Empty() && d.Users.Empty() && d.Groups.Empty() && d.ILMExpiryRules.Empty()
+}
+
+// Diff returns the entities whose replication status changed since prev. As
+// the server only reports the status of entities with mismatches, unless
+// specific entities were requested, an added entity is usually one that went
+// out of sync and a removed entity one that is back in sync.
+func (s SRStatusInfo) Diff(prev SRStatusInfo) SRStatusDiff {
+	return SRStatusDiff{
+		Buckets:        diffSREntities(s.BucketStats, prev.BucketStats),
+		Policies:       diffSREntities(s.PolicyStats, prev.PolicyStats),
+		Users:          diffSREntities(s.UserStats, prev.UserStats),
+		Groups:         diffSREntities(s.GroupStats, prev.GroupStats),
+		ILMExpiryRules: diffSREntities(s.ILMExpiryStats, prev.ILMExpiryStats),
+	}
+}
+
+// diffSREntities returns the sorted entities added, removed or changed in cur
+// compared to prev. Both map an entity to its status summary on every site.
+func diffSREntities[T comparable](cur, prev map[string]map[string]T) (d SREntityDiff) {
+	for name, sites := range cur {
+		prevSites, ok := prev[name]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, name)
+		case !maps.Equal(sites, prevSites):
+			d.Changed = append(d.Changed, name)
+		}
+	}
+	for name := range prev {
+		if _, ok := cur[name]; !ok {
+			d.Removed = append(d.Removed, name)
+		}
+	}
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	sort.Strings(d.Changed)
+	return d
+}
+
 // SRPolicyStatsSummary has status of policy replication misses
 type SRPolicyStatsSummary struct {
 	DeploymentID   string
@@ -1223,6 +1290,129 @@ func (adm *AdminClient) SiteReplicationResyncOp(ctx context.Context, site PeerIn
 	return res, err
 }
 
+// CancelResult is the result of cancelling the site replication resyncs
+// to all peer sites.
+type CancelResult struct {
+	// Cancelled is the number of peer sites whose resync was cancelled.
+	Cancelled int `json:"cancelled"`
+	// Peers holds the cancel status by peer deployment ID, peer sites
+	// without a resync in progress have the SRResyncNotRunning status.
+	Peers map[string]SRResyncOpStatus `json:"peers,omitempty"`
+	// NotRunning holds the deployment IDs of the peer sites that had
+	// no resync in progress.
+	NotRunning []string `json:"notRunning,omitempty"`
+	// Errors holds the error by peer deployment ID of the peer sites
+	// whose resync could not be cancelled.
+	Errors map[string]error `json:"-"`
+}
+
+// cancelResyncsWorkers is the number of peer sites CancelAllReplicationResyncs
+// cancels concurrently.
+const cancelResyncsWorkers = 8
+
+// SRResyncNotRunning is the status CancelAllReplicationResyncs reports
+// for a peer site without a resync in progress.
+const SRResyncNotRunning = "NotRunning"
+
+// errCodeSRInvalidRequest is returned by the server when cancelling the
+// resync to a peer site that has none in progress.
+const errCodeSRInvalidRequest = "XMinioSiteReplicationInvalidRequest"
+
+// isNoResyncErr returns the error response if err reports that there is
+// no resync to cancel.
+func isNoResyncErr(err error) (ErrorResponse, bool) {
+	var errResp ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Code != errCodeSRInvalidRequest {
+		return ErrorResponse{}, false
+	}
+	return errResp, true
+}
+
+// CancelAllReplicationResyncs - cancels the site replication resyncs from
+// this site to all of its peer sites, up to eight of them concurrently.
+// Peer sites without a resync in progress are not an error. The outcome of
+// every peer is returned in the result; the error is only set if the site
+// replication info could not be fetched or the context was cancelled
+// before all peers were processed.
+func (adm *AdminClient) CancelAllReplicationResyncs(ctx context.Context) (CancelResult, error) {
+	info, err := adm.SiteReplicationInfo(ctx)
+	if err != nil {
+		return CancelResult{}, err
+	}
+
+	// info.Name is the name of this site.
+	var peers []PeerInfo
+	for _, peer := range info.Sites {
+		if peer.Name != info.Name {
+			peers = append(peers, peer)
+		}
+	}
+
+	sts := make([]SRResyncOpStatus, len(peers))
+	errs := make([]error, len(peers))
+	runConcurrently(len(peers), cancelResyncsWorkers, func(i int) {
+		if errs[i] = ctx.Err(); errs[i] != nil {
+			return
+		}
+		sts[i], errs[i] = adm.SiteReplicationResyncOp(ctx, peers[i], SiteResyncCancel)
+	})
+
+	res := CancelResult{Peers: make(map[string]SRResyncOpStatus)}
+	for i, peer := range peers {
+		if errs[i] == nil {
+			res.Peers[peer.DeploymentID] = sts[i]
+			res.Cancelled++
+			continue
+		}
+		if errResp, ok := isNoResyncErr(errs[i]); ok {
+			res.Peers[peer.DeploymentID] = SRResyncOpStatus{
+				OpType:    string(SiteResyncCancel),
+				Status:    SRResyncNotRunning,
+				ErrDetail: errResp.Message,
+			}
+			res.NotRunning = append(res.NotRunning, peer.DeploymentID)
+			continue
+		}
+		if res.Errors == nil {
+			res.Errors = make(map[string]error)
+		}
+		res.Errors[peer.DeploymentID] = errs[i]
+	}
+	return res, ctx.Err()
+}
+
+// ReplicationResyncStatusStream - streams the progress of the site replication
+// resync to the peer site with deployment ID depID to out, every second until
+// the resync is complete. Site resyncs cover all buckets, so there is no per
+// bucket stream. The first error returned by out stops the stream and is returned.
+func (adm *AdminClient) ReplicationResyncStatusStream(ctx context.Context, depID string, out func(SiteResyncMetrics) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var outErr error
+	complete := false
+	err := adm.Metrics(ctx, MetricsOptions{Type: MetricsSiteResync, Interval: time.Second, ByDepID: depID}, func(m RealtimeMetrics) {
+		if outErr != nil || complete || m.Aggregated.SiteResync == nil {
+			return
+		}
+		if outErr = out(*m.Aggregated.SiteResync); outErr != nil {
+			cancel()
+			return
+		}
+		if m.Aggregated.SiteResync.Complete() {
+			complete = true
+			cancel()
+		}
+	})
+	switch {
+	case outErr != nil:
+		return outErr
+	case complete && errors.Is(err, context.Canceled):
+		return nil
+	}
+	return err
+}
+
 // SRMetric - captures replication metrics for a site replication peer
 type SRMetric struct {
 	DeploymentID  string        `json:"deploymentID"`
@@ -0,0 +1,203 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestReplicationResyncStatusStream(t *testing.T) {
+	samples := []SiteResyncMetrics{
+		{ResyncStatus: "Ongoing", ResyncID: "resync", DeplID: "peer", NumBuckets: 2, ReplicatedCount: 10, Bucket: "bucket-1"},
+		{ResyncStatus: "Ongoing", ResyncID: "resync", DeplID: "peer", NumBuckets: 2, ReplicatedCount: 25, Bucket: "bucket-2"},
+		{ResyncStatus: "Completed", ResyncID: "resync", DeplID: "peer", NumBuckets: 2, ReplicatedCount: 30},
+	}
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("by-depID") != "peer" || q.Get("types") != strconv.FormatUint(uint64(MetricsSiteResync), 10) {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		for i := range samples {
+			json.NewEncoder(w).Encode(RealtimeMetrics{Aggregated: Metrics{SiteResync: &samples[i]}})
+			w.(http.Flusher).Flush()
+		}
+		// Keep streaming until the client goes away.
+		<-r.Context().Done()
+	})
+
+	var got []SiteResyncMetrics
+	err := adm.ReplicationResyncStatusStream(context.Background(), "peer", func(m SiteResyncMetrics) error {
+		got = append(got, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(samples) {
+		t.Fatalf("expected %d updates, got %d: %+v", len(samples), len(got), got)
+	}
+	if got[1].ReplicatedCount != 25 || !got[2].Complete() {
+		t.Errorf("unexpected updates %+v", got)
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = adm.ReplicationResyncStatusStream(context.Background(), "peer", func(SiteResyncMetrics) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("expected the stream to stop after the first update, got %v after %d calls", err, calls)
+	}
+}
+
+func TestSRStatusInfoDiff(t *testing.T) {
+	prev := SRStatusInfo{
+		BucketStats: map[string]map[string]SRBucketStatsSummary{
+			"photos": {
+				"site1": {DeploymentID: "site1", HasBucket: true, TagMismatch: true},
+				"site2": {DeploymentID: "site2", HasBucket: true, TagMismatch: true},
+			},
+			"logs": {
+				"site1": {DeploymentID: "site1", HasBucket: true, QuotaCfgMismatch: true},
+			},
+		},
+		UserStats: map[string]map[string]SRUserStatsSummary{
+			"alice": {"site1": {DeploymentID: "site1", HasUser: true, PolicyMismatch: true}},
+		},
+		PolicyStats: map[string]map[string]SRPolicyStatsSummary{
+			"readonly": {"site1": {DeploymentID: "site1", HasPolicy: true, PolicyMismatch: true}},
+		},
+	}
+	cur := SRStatusInfo{
+		BucketStats: map[string]map[string]SRBucketStatsSummary{
+			// Status flip on one site.
+			"photos": {
+				"site1": {DeploymentID: "site1", HasBucket: true, TagMismatch: true},
+				"site2": {DeploymentID: "site2", HasBucket: true},
+			},
+			"backups": {
+				"site2": {DeploymentID: "site2", HasBucket: false},
+			},
+		},
+		UserStats: map[string]map[string]SRUserStatsSummary{
+			"alice": {"site1": {DeploymentID: "site1", HasUser: true, PolicyMismatch: true}},
+			"bob":   {"site2": {DeploymentID: "site2", UserInfoMismatch: true}},
+		},
+		GroupStats: map[string]map[string]SRGroupStatsSummary{
+			"devs": {"site1": {DeploymentID: "site1", HasGroup: true, GroupDescMismatch: true}},
+		},
+	}
+
+	diff := cur.Diff(prev)
+	want := SRStatusDiff{
+		Buckets:  SREntityDiff{Added: []string{"backups"}, Removed: []string{"logs"}, Changed: []string{"photos"}},
+		Policies: SREntityDiff{Removed: []string{"readonly"}},
+		Users:    SREntityDiff{Added: []string{"bob"}},
+		Groups:   SREntityDiff{Added: []string{"devs"}},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("expected %+v, got %+v", want, diff)
+	}
+	if diff.Empty() {
+		t.Error("expected a non-empty diff")
+	}
+	if d := cur.Diff(cur); !d.Empty() {
+		t.Errorf("expected no changes against itself, got %+v", d)
+	}
+}
+
+func TestCancelAllReplicationResyncs(t *testing.T) {
+	var mu sync.Mutex
+	var cancelled []string
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var v interface{}
+		switch r.URL.Path {
+		case "/minio/admin/v3/site-replication/info":
+			v = SiteReplicationInfo{
+				Enabled: true,
+				Name:    "site1",
+				Sites: []PeerInfo{
+					{Name: "site1", DeploymentID: "dep-1"},
+					{Name: "site2", DeploymentID: "dep-2"},
+					{Name: "site3", DeploymentID: "dep-3"},
+					{Name: "site4", DeploymentID: "dep-4"},
+					{Name: "site5", DeploymentID: "dep-5"},
+				},
+			}
+		case "/minio/admin/v3/site-replication/resync/op":
+			if r.Method != http.MethodPut || r.URL.Query().Get("operation") != string(SiteResyncCancel) {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			}
+			var peer PeerInfo
+			if err := json.NewDecoder(r.Body).Decode(&peer); err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			cancelled = append(cancelled, peer.DeploymentID)
+			mu.Unlock()
+			switch peer.DeploymentID {
+			case "dep-3":
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"Code":"XMinioSiteReplicationInvalidRequest","Message":"Invalid site replication request: resync already canceled"}`))
+				return
+			case "dep-4":
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"Code":"AccessDenied","Message":"Access Denied."}`))
+				return
+			}
+			v = SRResyncOpStatus{OpType: "cancel", ResyncID: "r-" + peer.DeploymentID, Status: "Canceled"}
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		json.NewEncoder(w).Encode(v)
+	})
+
+	res, err := adm.CancelAllReplicationResyncs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Cancelled != 2 || len(res.Peers) != 3 || res.Peers["dep-5"].ResyncID != "r-dep-5" {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if st := res.Peers["dep-3"]; st.Status != SRResyncNotRunning || st.ErrDetail == "" {
+		t.Errorf("expected dep-3 to be reported as not running, got %+v", st)
+	}
+	if want := []string{"dep-3"}; !reflect.DeepEqual(res.NotRunning, want) {
+		t.Errorf("expected %v without a resync, got %v", want, res.NotRunning)
+	}
+	if len(res.Errors) != 1 || res.Errors["dep-4"] == nil {
+		t.Errorf("expected an error for dep-4 only, got %v", res.Errors)
+	}
+	sort.Strings(cancelled)
+	if want := []string{"dep-2", "dep-3", "dep-4", "dep-5"}; !reflect.DeepEqual(cancelled, want) {
+		t.Errorf("expected cancel requests for %v, got %v", want, cancelled)
+	}
+}
@@ -0,0 +1,95 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Config keys of the compression sub-system.
+const (
+	CompressionAllowEncryptionKey = "allow_encryption"
+	CompressionExtensionsKey      = "extensions"
+	CompressionMimeTypesKey       = "mime_types"
+)
+
+// CompressionConfig holds the object compression settings of the server.
+type CompressionConfig struct {
+	Enabled         bool     `json:"enabled"`
+	AllowEncryption bool     `json:"allowEncryption"`
+	Extensions      []string `json:"extensions,omitempty"` // File extensions to compress, e.g. ".txt"
+	MimeTypes       []string `json:"mimeTypes,omitempty"`  // Content types to compress, e.g. "text/*"
+}
+
+// Validate returns an error if the compression configuration is invalid.
+func (c CompressionConfig) Validate() error {
+	for _, ext := range c.Extensions {
+		if !strings.HasPrefix(ext, ".") || len(ext) == 1 || HasSpace(ext) || strings.ContainsAny(ext, `,"`) {
+			return fmt.Errorf("invalid compression extension %q: must start with '.'", ext)
+		}
+	}
+	for _, mime := range c.MimeTypes {
+		typ, sub, ok := strings.Cut(mime, "/")
+		if !ok || typ == "" || sub == "" || HasSpace(mime) || strings.ContainsAny(mime, `,"`) {
+			return fmt.Errorf("invalid compression mime type %q", mime)
+		}
+	}
+	return nil
+}
+
+func (c CompressionConfig) kvs() []string {
+	return []string{
+		EnableKey + KvSeparator + formatBool(c.Enabled),
+		CompressionAllowEncryptionKey + KvSeparator + formatBool(c.AllowEncryption),
+		CompressionExtensionsKey + KvSeparator + KvDoubleQuote + strings.Join(c.Extensions, ",") + KvDoubleQuote,
+		CompressionMimeTypesKey + KvSeparator + KvDoubleQuote + strings.Join(c.MimeTypes, ",") + KvDoubleQuote,
+	}
+}
+
+func parseCompressionConfig(cfg SubsysConfig) (c CompressionConfig, err error) {
+	if c.Enabled, err = lookupBool(cfg, EnableKey); err != nil {
+		return c, err
+	}
+	if c.AllowEncryption, err = lookupBool(cfg, CompressionAllowEncryptionKey); err != nil {
+		return c, err
+	}
+	c.Extensions = lookupList(cfg, CompressionExtensionsKey)
+	c.MimeTypes = lookupList(cfg, CompressionMimeTypesKey)
+	return c, nil
+}
+
+// GetCompressionConfig - returns the object compression settings of the server.
+func (adm *AdminClient) GetCompressionConfig(ctx context.Context) (CompressionConfig, error) {
+	cfg, err := adm.getSubsysConfig(ctx, CompressionSubSys)
+	if err != nil {
+		return CompressionConfig{}, err
+	}
+	return parseCompressionConfig(cfg)
+}
+
+// SetCompressionConfig - sets the object compression settings of the server.
+func (adm *AdminClient) SetCompressionConfig(ctx context.Context, c CompressionConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	return adm.setSubsysConfig(ctx, CompressionSubSys, c.kvs()...)
+}
@@ -0,0 +1,91 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompressionConfigRoundTrip(t *testing.T) {
+	tests := []CompressionConfig{
+		{},
+		{Enabled: true, Extensions: []string{".txt", ".log", ".csv"}},
+		{
+			Enabled:         true,
+			AllowEncryption: true,
+			Extensions:      []string{".json"},
+			MimeTypes:       []string{"text/*", "application/json"},
+		},
+	}
+	for _, want := range tests {
+		if err := want.Validate(); err != nil {
+			t.Fatalf("%+v: unexpected validation error: %v", want, err)
+		}
+		cfgs, err := ParseServerConfigOutput(CompressionSubSys + " " + strings.Join(want.kvs(), " "))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parseCompressionConfig(cfgs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestCompressionConfigValidate(t *testing.T) {
+	tests := []CompressionConfig{
+		{Extensions: []string{"txt"}},
+		{Extensions: []string{"."}},
+		{Extensions: []string{".txt,.log"}},
+		{MimeTypes: []string{"text"}},
+		{MimeTypes: []string{"text/"}},
+		{MimeTypes: []string{"text/ plain"}},
+	}
+	for _, c := range tests {
+		if err := c.Validate(); err == nil {
+			t.Errorf("%+v: expected validation error", c)
+		}
+	}
+}
+
+func TestParseCompressionConfigServerOutput(t *testing.T) {
+	cfgs, err := ParseServerConfigOutput(`# MINIO_COMPRESSION_ENABLE=on
+compression enable=off allow_encryption=off extensions=".txt,.log" mime_types="text/*"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := parseCompressionConfig(cfgs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := CompressionConfig{
+		Enabled:    true,
+		Extensions: []string{".txt", ".log"},
+		MimeTypes:  []string{"text/*"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
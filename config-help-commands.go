@@ -22,6 +22,7 @@ package madmin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
 )
@@ -90,3 +91,130 @@ func (adm *AdminClient) HelpConfigKV(ctx context.Context, subSys, key string, en
 
 	return help, nil
 }
+
+// configSchemaWorkers is the number of sub-systems ConfigSchema fetches
+// the help of concurrently.
+const configSchemaWorkers = 8
+
+// errCodeConfigError is returned by the server for invalid or unknown
+// sub-systems.
+const errCodeConfigError = "XMinioConfigError"
+
+// SubsysSchema describes a configuration sub-system and its keys.
+type SubsysSchema struct {
+	SubSys          string      `json:"subSys"`
+	Description     string      `json:"description"`
+	MultipleTargets bool        `json:"multipleTargets"`
+	Keys            []KeySchema `json:"keys"`
+}
+
+// KeySchema describes a single key of a configuration sub-system.
+// Default and Sensitive are not reported by the server yet and are
+// left empty.
+type KeySchema struct {
+	Key             string `json:"key"`
+	Description     string `json:"description"`
+	Type            string `json:"type"`
+	Optional        bool   `json:"optional"`
+	MultipleTargets bool   `json:"multipleTargets"`
+	Default         string `json:"default,omitempty"`
+	Sensitive       bool   `json:"sensitive,omitempty"`
+}
+
+func subsysSchemaFromHelp(h Help) SubsysSchema {
+	s := SubsysSchema{
+		SubSys:          h.SubSys,
+		Description:     h.Description,
+		MultipleTargets: h.MultipleTargets,
+		Keys:            make([]KeySchema, 0, len(h.KeysHelp)),
+	}
+	for _, kh := range h.KeysHelp {
+		s.Keys = append(s.Keys, KeySchema{
+			Key:             kh.Key,
+			Description:     kh.Description,
+			Type:            kh.Type,
+			Optional:        kh.Optional,
+			MultipleTargets: kh.MultipleTargets,
+		})
+	}
+	return s
+}
+
+// ConfigSchema - returns the schema of all known sub-systems, sorted by
+// sub-system, describing the type of every key and whether it is optional.
+// Sub-systems the server does not know are left out, any other error is
+// returned.
+func (adm *AdminClient) ConfigSchema(ctx context.Context) ([]SubsysSchema, error) {
+	subSystems := SubSystems.ToSlice()
+	helps := make([]Help, len(subSystems))
+	errs := make([]error, len(subSystems))
+	runConcurrently(len(subSystems), configSchemaWorkers, func(i int) {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			return
+		}
+		helps[i], errs[i] = adm.HelpConfigKV(ctx, subSystems[i], "", false)
+	})
+
+	schema := make([]SubsysSchema, 0, len(subSystems))
+	for i, err := range errs {
+		var errResp ErrorResponse
+		switch {
+		case err == nil:
+			schema = append(schema, subsysSchemaFromHelp(helps[i]))
+		case errors.As(err, &errResp) && errResp.Code == errCodeConfigError:
+			// Sub-system not supported by this server.
+		default:
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+// SubsysConfigWithHelp is the configuration of a sub-system target along
+// with the help of the sub-system, describing its keys.
+type SubsysConfigWithHelp struct {
+	Config SubsysConfig `json:"config"`
+	Help   Help         `json:"help"`
+}
+
+// KeyHelp returns the help of key, false is returned if the sub-system
+// help does not describe the key.
+func (c SubsysConfigWithHelp) KeyHelp(key string) (HelpKV, bool) {
+	for _, kh := range c.Help.KeysHelp {
+		if kh.Key == key {
+			return kh, true
+		}
+	}
+	return HelpKV{}, false
+}
+
+// GetLogConfigFor - returns the configuration of all targets of the log
+// sub-system subSys, e.g. LoggerWebhookSubSys or AuditKafkaSubSys, with
+// the help of the sub-system attached. The sub-system is not checked
+// against the known log sub-systems, so newer ones are supported too.
+func (adm *AdminClient) GetLogConfigFor(ctx context.Context, subSys string) ([]SubsysConfigWithHelp, error) {
+	if subSys == "" {
+		return nil, ErrInvalidArgument("sub-system cannot be empty")
+	}
+	buf, err := adm.GetConfigKV(ctx, subSys)
+	if err != nil {
+		return nil, err
+	}
+	cfgs, err := ParseServerConfigOutput(string(buf))
+	if err != nil {
+		return nil, err
+	}
+	help, err := adm.HelpConfigKV(ctx, subSys, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]SubsysConfigWithHelp, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		if cfg.SubSystem == subSys {
+			res = append(res, SubsysConfigWithHelp{Config: cfg, Help: help})
+		}
+	}
+	return res, nil
+}
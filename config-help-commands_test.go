@@ -0,0 +1,147 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"testing"
+)
+
+func TestConfigSchema(t *testing.T) {
+	helps := map[string]Help{
+		RegionSubSys: {
+			SubSys:      RegionSubSys,
+			Description: "label the location of the server",
+			KeysHelp: HelpKVS{
+				{Key: "name", Description: "name of the location of the server", Optional: true, Type: "string"},
+				{Key: "comment", Description: "optionally add a comment to this setting", Optional: true, Type: "sentence"},
+			},
+		},
+		NotifyWebhookSubSys: {
+			SubSys:          NotifyWebhookSubSys,
+			Description:     "publish bucket notifications to webhook endpoints",
+			MultipleTargets: true,
+			KeysHelp: HelpKVS{
+				{Key: "endpoint", Description: "webhook server endpoint", Type: "url"},
+				{Key: "auth_token", Description: "opaque string or JWT authorization token", Optional: true, Type: "string"},
+			},
+		},
+	}
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		help, ok := helps[r.URL.Query().Get("subSys")]
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"Code":"XMinioConfigError","Message":"unknown sub-system"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(help)
+	})
+
+	schema, err := adm.ConfigSchema(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schema) != len(helps) {
+		t.Fatalf("expected %d sub-systems, got %d: %+v", len(helps), len(schema), schema)
+	}
+	if !sort.SliceIsSorted(schema, func(i, j int) bool { return schema[i].SubSys < schema[j].SubSys }) {
+		t.Errorf("expected sub-systems to be sorted, got %+v", schema)
+	}
+	for _, help := range schema {
+		want := helps[help.SubSys]
+		if help.Description != want.Description || len(help.Keys) != len(want.KeysHelp) {
+			t.Errorf("%s: expected %+v, got %+v", help.SubSys, want, help)
+		}
+	}
+	if schema[0].SubSys != NotifyWebhookSubSys || !schema[0].MultipleTargets || schema[0].Keys[0].Type != "url" {
+		t.Errorf("unexpected webhook schema %+v", schema[0])
+	}
+}
+
+func TestConfigSchemaError(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"Code":"AccessDenied","Message":"Access Denied."}`))
+	})
+
+	schema, err := adm.ConfigSchema(context.Background())
+	var errResp ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Code != "AccessDenied" {
+		t.Fatalf("expected AccessDenied error, got %v (schema %+v)", err, schema)
+	}
+}
+
+func TestGetLogConfigFor(t *testing.T) {
+	help := Help{
+		SubSys:          AuditWebhookSubSys,
+		Description:     "send audit logs to webhook endpoints",
+		MultipleTargets: true,
+		KeysHelp: HelpKVS{
+			{Key: "enable", Description: "enable audit_webhook target", Type: "on|off"},
+			{Key: "endpoint", Description: "HTTP(s) endpoint e.g. \"http://localhost:8080/minio/logs/audit\"", Type: "url"},
+		},
+	}
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/minio/admin/v3/get-config-kv":
+			if key := r.URL.Query().Get("key"); key != AuditWebhookSubSys {
+				t.Errorf("unexpected key %q", key)
+			}
+			data, err := EncryptData("minioadmin", []byte("audit_webhook enable=off endpoint=\naudit_webhook:splunk enable=on endpoint=http://splunk:8088\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.Write(data)
+		case "/minio/admin/v3/help-config-kv":
+			if subSys := r.URL.Query().Get("subSys"); subSys != AuditWebhookSubSys {
+				t.Errorf("unexpected sub-system %q", subSys)
+			}
+			json.NewEncoder(w).Encode(help)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	cfgs, err := adm.GetLogConfigFor(context.Background(), AuditWebhookSubSys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfgs) != 2 {
+		t.Fatalf("expected 2 targets, got %+v", cfgs)
+	}
+	if cfgs[0].Config.Target != "" || cfgs[1].Config.Target != "splunk" {
+		t.Errorf("unexpected targets %q, %q", cfgs[0].Config.Target, cfgs[1].Config.Target)
+	}
+	if v, _ := cfgs[1].Config.Lookup("endpoint"); v != "http://splunk:8088" {
+		t.Errorf("unexpected endpoint %q", v)
+	}
+	if kh, ok := cfgs[1].KeyHelp("endpoint"); !ok || kh.Type != "url" {
+		t.Errorf("unexpected endpoint help %+v", kh)
+	}
+	if _, ok := cfgs[0].KeyHelp("unknown"); ok {
+		t.Error("expected no help for unknown key")
+	}
+}
@@ -21,8 +21,12 @@ package madmin
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // DelConfigKV - delete key from server config.
@@ -144,3 +148,114 @@ func (adm *AdminClient) GetConfigKVWithOptions(ctx context.Context, key string,
 
 	return DecryptData(adm.getSecretKey(), resp.Body)
 }
+
+// getSubsysConfig - fetches the configuration of the default target of the
+// given sub-system and returns it in parsed form.
+func (adm *AdminClient) getSubsysConfig(ctx context.Context, subSys string) (SubsysConfig, error) {
+	buf, err := adm.GetConfigKV(ctx, subSys)
+	if err != nil {
+		return SubsysConfig{}, err
+	}
+
+	cfgs, err := ParseServerConfigOutput(string(buf))
+	if err != nil {
+		return SubsysConfig{}, err
+	}
+
+	for _, cfg := range cfgs {
+		if cfg.SubSystem == subSys && cfg.Target == "" {
+			return cfg, nil
+		}
+	}
+	return SubsysConfig{}, fmt.Errorf("no configuration found for sub-system %q", subSys)
+}
+
+// setSubsysConfig - applies the given key=value pairs to the default target
+// of the given sub-system.
+func (adm *AdminClient) setSubsysConfig(ctx context.Context, subSys string, kvs ...string) error {
+	_, err := adm.SetConfigKV(ctx, subSys+KvSpaceSeparator+strings.Join(kvs, KvSpaceSeparator))
+	return err
+}
+
+// lookupInt returns the integer value of key in cfg, or 0 if it is unset.
+func lookupInt(cfg SubsysConfig, key string) (int, error) {
+	v, ok := cfg.Lookup(key)
+	if !ok || v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s value %q: %w", cfg.SubSystem, key, v, err)
+	}
+	return n, nil
+}
+
+// lookupDuration returns the duration value of key in cfg, or 0 if it is unset.
+func lookupDuration(cfg SubsysConfig, key string) (time.Duration, error) {
+	v, ok := cfg.Lookup(key)
+	if !ok || v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s value %q: %w", cfg.SubSystem, key, v, err)
+	}
+	return d, nil
+}
+
+// lookupList returns the comma separated values of key in cfg.
+func lookupList(cfg SubsysConfig, key string) []string {
+	v, _ := cfg.Lookup(key)
+	var list []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// lookupBool returns the boolean value of key in cfg, or false if it is unset.
+func lookupBool(cfg SubsysConfig, key string) (bool, error) {
+	v, ok := cfg.Lookup(key)
+	if !ok || v == "" {
+		return false, nil
+	}
+	switch strings.ToLower(v) {
+	case EnableOn:
+		return true, nil
+	case EnableOff:
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %s value %q: %w", cfg.SubSystem, key, v, err)
+	}
+	return b, nil
+}
+
+// formatBool returns the config value for b.
+func formatBool(b bool) string {
+	if b {
+		return EnableOn
+	}
+	return EnableOff
+}
+
+// formatInt returns the config value for n, zero is left empty so
+// the server keeps its default.
+func formatInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// formatDuration returns the config value for d, zero is left empty so
+// the server keeps its default.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
@@ -0,0 +1,55 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"net/http"
+	"testing"
+)
+
+// newTestConfigKVClient returns a client of a server holding config, the
+// server output of subSys. The returned pointer is set to the decrypted
+// body of the last set-config-kv request.
+func newTestConfigKVClient(t *testing.T, subSys, config string) (*AdminClient, *string) {
+	t.Helper()
+	received := new(string)
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/minio/admin/v3/get-config-kv":
+			if key := r.URL.Query().Get("key"); key != subSys {
+				t.Errorf("expected config of %s, got %s", subSys, key)
+			}
+			data, err := EncryptData("minioadmin", []byte(config))
+			if err != nil {
+				t.Error(err)
+			}
+			w.Write(data)
+		case r.Method == http.MethodPut && r.URL.Path == "/minio/admin/v3/set-config-kv":
+			data, err := DecryptData("minioadmin", r.Body)
+			if err != nil {
+				t.Error(err)
+			}
+			*received = string(data)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+	return adm, received
+}
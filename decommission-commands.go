@@ -22,6 +22,7 @@ package madmin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
 	"time"
@@ -43,6 +44,28 @@ type PoolDecommissionInfo struct {
 	BytesFailed               int64 `json:"bytesDecommissionedFailed"`
 }
 
+// ETA returns the estimated time remaining until the decommission completes,
+// extrapolated from the bytes decommissioned since StartTime. false is
+// returned if the decommission is no longer running or nothing was
+// decommissioned yet to estimate from.
+func (p PoolDecommissionInfo) ETA(now time.Time) (time.Duration, bool) {
+	if p.Complete {
+		return 0, true
+	}
+	if p.Failed || p.Canceled || p.StartTime.IsZero() || p.BytesDone <= 0 {
+		return 0, false
+	}
+	elapsed := now.Sub(p.StartTime)
+	if elapsed <= 0 {
+		return 0, false
+	}
+	remaining := p.StartSize - p.BytesDone
+	if remaining <= 0 {
+		return 0, true
+	}
+	return time.Duration(float64(elapsed) * float64(remaining) / float64(p.BytesDone)), true
+}
+
 // PoolStatus captures current pool status
 type PoolStatus struct {
 	ID           int                   `json:"id"`
@@ -138,3 +161,84 @@ func (adm *AdminClient) ListPoolsStatus(ctx context.Context) ([]PoolStatus, erro
 	}
 	return pools, nil
 }
+
+// PoolCommand is a cluster wide operation on the server pools.
+type PoolCommand string
+
+// Pool commands reported by PoolCommandStatus.
+const (
+	PoolCommandNone         PoolCommand = ""
+	PoolCommandDecommission PoolCommand = "decommission"
+	PoolCommandRebalance    PoolCommand = "rebalance"
+)
+
+// errCodeRebalanceNotStarted is returned by RebalanceStatus when
+// no rebalance was ever started on the cluster.
+const errCodeRebalanceNotStarted = "XMinioAdminRebalanceNotStarted"
+
+// PoolCommandStatus holds the pool command running on the cluster, if any.
+type PoolCommandStatus struct {
+	Command      PoolCommand      `json:"command,omitempty"`
+	Pools        []int            `json:"pools,omitempty"`        // IDs of the pools the command runs on
+	PercentDone  float64          `json:"percentDone"`            // Progress across all of the pools
+	Decommission []PoolStatus     `json:"decommission,omitempty"` // Status of the pools being decommissioned
+	Rebalance    *RebalanceStatus `json:"rebalance,omitempty"`
+}
+
+// PoolCommandStatus - returns the pool command, decommission or rebalance,
+// running on the cluster along with its progress. Command is empty if none
+// is running.
+func (adm *AdminClient) PoolCommandStatus(ctx context.Context) (PoolCommandStatus, error) {
+	pools, err := adm.ListPoolsStatus(ctx)
+	if err != nil {
+		return PoolCommandStatus{}, err
+	}
+	if status, ok := decommissionCommandStatus(pools); ok {
+		return status, nil
+	}
+
+	rebalance, err := adm.RebalanceStatus(ctx)
+	if err != nil {
+		var errResp ErrorResponse
+		if errors.As(err, &errResp) && errResp.Code == errCodeRebalanceNotStarted {
+			return PoolCommandStatus{}, nil
+		}
+		return PoolCommandStatus{}, err
+	}
+	if rebalance.done() {
+		return PoolCommandStatus{}, nil
+	}
+	status := PoolCommandStatus{Command: PoolCommandRebalance, Rebalance: &rebalance}
+	for _, p := range rebalance.Pools {
+		if p.Status == rebalanceStartedStatus {
+			status.Pools = append(status.Pools, p.ID)
+			status.PercentDone += p.PercentDone()
+		}
+	}
+	status.PercentDone /= float64(len(status.Pools))
+	return status, nil
+}
+
+// decommissionCommandStatus returns the status of the pools being
+// decommissioned, false is returned if none is.
+func decommissionCommandStatus(pools []PoolStatus) (PoolCommandStatus, bool) {
+	status := PoolCommandStatus{Command: PoolCommandDecommission}
+	var startSize, bytesDone int64
+	for _, p := range pools {
+		d := p.Decommission
+		if d == nil || d.Complete || d.Failed || d.Canceled {
+			continue
+		}
+		status.Pools = append(status.Pools, p.ID)
+		status.Decommission = append(status.Decommission, p)
+		startSize += d.StartSize
+		bytesDone += d.BytesDone
+	}
+	if len(status.Pools) == 0 {
+		return PoolCommandStatus{}, false
+	}
+	if startSize > 0 {
+		status.PercentDone = min(100, 100*float64(bytesDone)/float64(startSize))
+	}
+	return status, true
+}
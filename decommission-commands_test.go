@@ -0,0 +1,142 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPoolDecommissionInfoETA(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	now := start.Add(time.Hour)
+	testCases := []struct {
+		name    string
+		info    PoolDecommissionInfo
+		wantETA time.Duration
+		wantOK  bool
+	}{
+		{
+			name: "just started",
+			info: PoolDecommissionInfo{StartTime: now, StartSize: 1 << 40},
+		},
+		{
+			name:    "halfway",
+			info:    PoolDecommissionInfo{StartTime: start, StartSize: 1 << 40, BytesDone: 1 << 39},
+			wantETA: time.Hour,
+			wantOK:  true,
+		},
+		{
+			name:    "quarter done",
+			info:    PoolDecommissionInfo{StartTime: start, StartSize: 1 << 40, BytesDone: 1 << 38},
+			wantETA: 3 * time.Hour,
+			wantOK:  true,
+		},
+		{
+			name:   "completed",
+			info:   PoolDecommissionInfo{StartTime: start, StartSize: 1 << 40, BytesDone: 1 << 40, Complete: true},
+			wantOK: true,
+		},
+		{
+			name: "canceled",
+			info: PoolDecommissionInfo{StartTime: start, StartSize: 1 << 40, BytesDone: 1 << 39, Canceled: true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eta, ok := tc.info.ETA(now)
+			if eta != tc.wantETA || ok != tc.wantOK {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tc.wantETA, tc.wantOK, eta, ok)
+			}
+		})
+	}
+}
+
+func TestPoolCommandStatus(t *testing.T) {
+	state := PoolCommandDecommission
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/minio/admin/v3/pools/list":
+			if state != PoolCommandDecommission {
+				w.Write([]byte(`[{"id":0,"cmdline":"http://server{1...4}/disk{1...4}"},{"id":1,"cmdline":"http://server{5...8}/disk{1...4}"}]`))
+				return
+			}
+			w.Write([]byte(`[
+	{"id":0,"cmdline":"http://server{1...4}/disk{1...4}","lastUpdate":"2024-05-01T11:00:00Z","decommissionInfo":{"startTime":"2024-05-01T10:00:00Z","startSize":4000,"totalSize":10000,"currentSize":7000,"objectsDecommissioned":30,"bytesDecommissioned":1000}},
+	{"id":1,"cmdline":"http://server{5...8}/disk{1...4}","lastUpdate":"2024-05-01T11:00:00Z"}
+]`))
+		case "/minio/admin/v3/rebalance/status":
+			if state != PoolCommandRebalance {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"Code":"XMinioAdminRebalanceNotStarted","Message":"Pool rebalance is not started"}`))
+				return
+			}
+			w.Write([]byte(`{"ID":"rebalance","pools":[{"id":0,"status":"Started","progress":{"elapsed":60000000000,"eta":180000000000}},{"id":1,"status":"Started","progress":{"elapsed":60000000000,"eta":60000000000}}]}`))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	})
+
+	status, err := adm.PoolCommandStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Command != PoolCommandDecommission || len(status.Pools) != 1 || status.Pools[0] != 0 {
+		t.Fatalf("expected decommission of pool 0, got %+v", status)
+	}
+	if status.PercentDone != 25 {
+		t.Errorf("expected 25%% done, got %v%%", status.PercentDone)
+	}
+	if len(status.Decommission) != 1 || status.Decommission[0].Decommission.ObjectsDecommissioned != 30 {
+		t.Errorf("unexpected decommission status %+v", status.Decommission)
+	}
+
+	state = PoolCommandNone
+	status, err = adm.PoolCommandStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Command != PoolCommandNone || status.Pools != nil {
+		t.Errorf("expected no pool command running, got %+v", status)
+	}
+
+	state = PoolCommandRebalance
+	status, err = adm.PoolCommandStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Command != PoolCommandRebalance || len(status.Pools) != 2 || status.PercentDone != 37.5 {
+		t.Errorf("expected rebalance of both pools 37.5%% done, got %+v", status)
+	}
+}
+
+func TestDecommissionCommandStatusIdle(t *testing.T) {
+	pools := []PoolStatus{
+		{ID: 0, Decommission: &PoolDecommissionInfo{Complete: true, StartSize: 100, BytesDone: 100}},
+		{ID: 1, Decommission: &PoolDecommissionInfo{Canceled: true}},
+		{ID: 2},
+	}
+	if status, ok := decommissionCommandStatus(pools); ok {
+		t.Errorf("expected no decommission running, got %+v", status)
+	}
+}
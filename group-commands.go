@@ -73,6 +73,9 @@ type GroupDesc struct {
 	Members   []string  `json:"members"`
 	Policy    string    `json:"policy"`
 	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+
+	// Err is set by GetGroupDescriptions if the group could not be fetched.
+	Err error `json:"-"`
 }
 
 // GetGroupDescription - fetches information on a group.
@@ -107,6 +110,32 @@ func (adm *AdminClient) GetGroupDescription(ctx context.Context, group string) (
 	return &gd, nil
 }
 
+// getGroupDescriptionsWorkers is the number of groups GetGroupDescriptions
+// fetches concurrently.
+const getGroupDescriptionsWorkers = 8
+
+// GetGroupDescriptions - fetches information on several groups, up to
+// eight of them concurrently. The results are returned in the same order
+// as groups. A group that could not be fetched has only its Name and Err
+// set, the remaining groups are still returned. The error is only set if
+// the context was cancelled before all groups were processed.
+func (adm *AdminClient) GetGroupDescriptions(ctx context.Context, groups ...string) ([]GroupDesc, error) {
+	descs := make([]GroupDesc, len(groups))
+	runConcurrently(len(groups), getGroupDescriptionsWorkers, func(i int) {
+		if err := ctx.Err(); err != nil {
+			descs[i] = GroupDesc{Name: groups[i], Err: err}
+			return
+		}
+		gd, err := adm.GetGroupDescription(ctx, groups[i])
+		if err != nil {
+			descs[i] = GroupDesc{Name: groups[i], Err: err}
+			return
+		}
+		descs[i] = *gd
+	})
+	return descs, ctx.Err()
+}
+
 // ListGroups - lists all groups names present on the server.
 func (adm *AdminClient) ListGroups(ctx context.Context) ([]string, error) {
 	reqData := requestData{
@@ -0,0 +1,71 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetGroupDescriptions(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		group := r.URL.Query().Get("group")
+		if group == "missing" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Code":"XMinioAdminNoSuchGroup","Message":"The specified group does not exist."}`))
+			return
+		}
+		json.NewEncoder(w).Encode(GroupDesc{
+			Name:    group,
+			Status:  "enabled",
+			Members: []string{group + "-member"},
+			Policy:  "readonly",
+		})
+	})
+
+	groups := []string{"dev", "missing", "ops"}
+	descs, err := adm.GetGroupDescriptions(context.Background(), groups...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(descs) != len(groups) {
+		t.Fatalf("expected %d results, got %d", len(groups), len(descs))
+	}
+	for i, gd := range descs {
+		if gd.Name != groups[i] {
+			t.Errorf("result %d: expected group %q, got %q", i, groups[i], gd.Name)
+		}
+	}
+	if descs[0].Err != nil || descs[2].Err != nil {
+		t.Errorf("unexpected errors %v, %v", descs[0].Err, descs[2].Err)
+	}
+	if descs[0].Members[0] != "dev-member" || descs[2].Policy != "readonly" {
+		t.Errorf("unexpected results %+v", descs)
+	}
+	if descs[1].Err == nil {
+		t.Fatal("expected an error for the missing group")
+	}
+	if code := ToErrorResponse(descs[1].Err).Code; code != "XMinioAdminNoSuchGroup" {
+		t.Errorf("expected XMinioAdminNoSuchGroup error, got %v", descs[1].Err)
+	}
+}
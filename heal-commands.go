@@ -62,6 +62,10 @@ type HealOpts struct {
 	Pool *int `json:"pool,omitempty"`
 	// Set to heal. nil indicates "all sets". Should always be nil if Pool is nil.
 	Set *int `json:"set,omitempty"`
+
+	// Prefix to heal in the bucket given to Heal, used if no object prefix
+	// is passed to Heal. Set Recursive to heal everything below the prefix.
+	Prefix string `json:"prefix,omitempty"`
 }
 
 // Equal returns true if no is same as o.
@@ -81,6 +85,9 @@ func (o HealOpts) Equal(no HealOpts) bool {
 	if o.UpdateParity != no.UpdateParity {
 		return false
 	}
+	if o.Prefix != no.Prefix {
+		return false
+	}
 
 	return o.ScanMode == no.ScanMode
 }
@@ -107,6 +114,17 @@ type HealTaskStatus struct {
 	Items []HealResultItem `json:"items,omitempty"`
 }
 
+// Summary values of a heal sequence that is no longer running.
+const (
+	healFinishedStatus = "finished"
+	healStoppedStatus  = "stopped"
+)
+
+// done returns whether the heal sequence has ended.
+func (s HealTaskStatus) done() bool {
+	return s.Summary == healFinishedStatus || s.Summary == healStoppedStatus || s.FailureDetail != ""
+}
+
 // HealItemType - specify the type of heal operation in a healing
 // result
 type HealItemType string
@@ -241,6 +259,11 @@ func (hri *HealResultItem) GetOnlineCounts() (b, a int) {
 // forceStart and forceStop are mutually exclusive, you can either
 // set one of them to 'true'. If both are set 'forceStart' will be
 // honored.
+//
+// forceStop stops the heal sequence running for bucket and prefix, the
+// server cannot stop a heal sequence by its client token. Fetching the
+// status with the client token consumes the queued heal results of the
+// sequence, so it is not a safe way to check a token before stopping.
 func (adm *AdminClient) Heal(ctx context.Context, bucket, prefix string,
 	healOpts HealOpts, clientToken string, forceStart, forceStop bool) (
 	healStart HealStartSuccess, healTaskStatus HealTaskStatus, err error,
@@ -249,14 +272,14 @@ func (adm *AdminClient) Heal(ctx context.Context, bucket, prefix string,
 		return healStart, healTaskStatus, ErrInvalidArgument("forceStart and forceStop set to true is not allowed")
 	}
 
-	body, err := json.Marshal(healOpts)
+	path, err := healPath(bucket, prefix, healOpts)
 	if err != nil {
 		return healStart, healTaskStatus, err
 	}
 
-	path := fmt.Sprintf(adminAPIPrefix+"/heal/%s", bucket)
-	if bucket != "" && prefix != "" {
-		path += "/" + prefix
+	body, err := json.Marshal(healOpts)
+	if err != nil {
+		return healStart, healTaskStatus, err
 	}
 
 	// execute POST request to heal api
@@ -317,6 +340,78 @@ func (adm *AdminClient) Heal(ctx context.Context, bucket, prefix string,
 	return healStart, healTaskStatus, nil
 }
 
+// healWatchInterval is the interval at which HealWatch polls the status
+// of the heal sequence.
+var healWatchInterval = time.Second
+
+// HealWatch - starts a heal sequence and sends every new status of it
+// to the returned status channel, until the sequence has finished or
+// ctx is canceled. Any error, including cancellation of ctx, is sent to
+// the error channel. Both channels are closed once watching has ended.
+func (adm *AdminClient) HealWatch(ctx context.Context, bucket, prefix string, opts HealOpts) (<-chan HealTaskStatus, <-chan error) {
+	statusCh := make(chan HealTaskStatus)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(errCh)
+		defer close(statusCh)
+
+		healStart, _, err := adm.Heal(ctx, bucket, prefix, opts, "", false, false)
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		var last HealTaskStatus
+		for {
+			_, status, err := adm.Heal(ctx, bucket, prefix, opts, healStart.ClientToken, false, false)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if len(status.Items) > 0 || status.Summary != last.Summary || status.FailureDetail != last.FailureDetail {
+				select {
+				case statusCh <- status:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+				last = status
+			}
+			if status.done() {
+				return
+			}
+
+			select {
+			case <-time.After(healWatchInterval):
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return statusCh, errCh
+}
+
+// healPath returns the heal API path for the given bucket and prefix,
+// falling back to the prefix of opts if prefix is empty.
+func healPath(bucket, prefix string, opts HealOpts) (string, error) {
+	if opts.Prefix != "" {
+		if bucket == "" {
+			return "", ErrInvalidArgument("a heal prefix requires a bucket")
+		}
+		if prefix != "" && prefix != opts.Prefix {
+			return "", ErrInvalidArgument("conflicting heal prefixes " + prefix + " and " + opts.Prefix)
+		}
+		prefix = opts.Prefix
+	}
+
+	path := fmt.Sprintf(adminAPIPrefix+"/heal/%s", bucket)
+	if bucket != "" && prefix != "" {
+		path += "/" + prefix
+	}
+	return path, nil
+}
+
 // MRFStatus exposes MRF metrics of a server
 type MRFStatus struct {
 	BytesHealed uint64 `json:"bytes_healed"`
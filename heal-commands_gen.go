@@ -794,7 +794,7 @@ func (z *HealOpts) DecodeMsg(dc *msgp.Reader) (err error) {
 		err = msgp.WrapError(err)
 		return
 	}
-	var zb0001Mask uint8 /* 2 bits */
+	var zb0001Mask uint8 /* 3 bits */
 	_ = zb0001Mask
 	for zb0001 > 0 {
 		zb0001--
@@ -888,6 +888,13 @@ func (z *HealOpts) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 			}
 			zb0001Mask |= 0x2
+		case "prefix":
+			z.Prefix, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Prefix")
+				return
+			}
+			zb0001Mask |= 0x4
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -897,13 +904,16 @@ func (z *HealOpts) DecodeMsg(dc *msgp.Reader) (err error) {
 		}
 	}
 	// Clear omitted fields.
-	if zb0001Mask != 0x3 {
+	if zb0001Mask != 0x7 {
 		if (zb0001Mask & 0x1) == 0 {
 			z.Pool = nil
 		}
 		if (zb0001Mask & 0x2) == 0 {
 			z.Set = nil
 		}
+		if (zb0001Mask & 0x4) == 0 {
+			z.Prefix = ""
+		}
 	}
 	return
 }
@@ -911,8 +921,8 @@ func (z *HealOpts) DecodeMsg(dc *msgp.Reader) (err error) {
 // EncodeMsg implements msgp.Encodable
 func (z *HealOpts) EncodeMsg(en *msgp.Writer) (err error) {
 	// check for omitted fields
-	zb0001Len := uint32(9)
-	var zb0001Mask uint16 /* 9 bits */
+	zb0001Len := uint32(10)
+	var zb0001Mask uint16 /* 10 bits */
 	_ = zb0001Mask
 	if z.Pool == nil {
 		zb0001Len--
@@ -922,6 +932,10 @@ func (z *HealOpts) EncodeMsg(en *msgp.Writer) (err error) {
 		zb0001Len--
 		zb0001Mask |= 0x100
 	}
+	if z.Prefix == "" {
+		zb0001Len--
+		zb0001Mask |= 0x200
+	}
 	// variable map header, size zb0001Len
 	err = en.Append(0x80 | uint8(zb0001Len))
 	if err != nil {
@@ -1038,6 +1052,18 @@ func (z *HealOpts) EncodeMsg(en *msgp.Writer) (err error) {
 				}
 			}
 		}
+		if (zb0001Mask & 0x200) == 0 { // if not omitted
+			// write "prefix"
+			err = en.Append(0xa6, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78)
+			if err != nil {
+				return
+			}
+			err = en.WriteString(z.Prefix)
+			if err != nil {
+				err = msgp.WrapError(err, "Prefix")
+				return
+			}
+		}
 	}
 	return
 }
@@ -1046,8 +1072,8 @@ func (z *HealOpts) EncodeMsg(en *msgp.Writer) (err error) {
 func (z *HealOpts) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// check for omitted fields
-	zb0001Len := uint32(9)
-	var zb0001Mask uint16 /* 9 bits */
+	zb0001Len := uint32(10)
+	var zb0001Mask uint16 /* 10 bits */
 	_ = zb0001Mask
 	if z.Pool == nil {
 		zb0001Len--
@@ -1057,6 +1083,10 @@ func (z *HealOpts) MarshalMsg(b []byte) (o []byte, err error) {
 		zb0001Len--
 		zb0001Mask |= 0x100
 	}
+	if z.Prefix == "" {
+		zb0001Len--
+		zb0001Mask |= 0x200
+	}
 	// variable map header, size zb0001Len
 	o = append(o, 0x80|uint8(zb0001Len))
 
@@ -1101,6 +1131,11 @@ func (z *HealOpts) MarshalMsg(b []byte) (o []byte, err error) {
 				o = msgp.AppendInt(o, *z.Set)
 			}
 		}
+		if (zb0001Mask & 0x200) == 0 { // if not omitted
+			// string "prefix"
+			o = append(o, 0xa6, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78)
+			o = msgp.AppendString(o, z.Prefix)
+		}
 	}
 	return
 }
@@ -1115,7 +1150,7 @@ func (z *HealOpts) UnmarshalMsg(bts []byte) (o []byte, err error) {
 		err = msgp.WrapError(err)
 		return
 	}
-	var zb0001Mask uint8 /* 2 bits */
+	var zb0001Mask uint8 /* 3 bits */
 	_ = zb0001Mask
 	for zb0001 > 0 {
 		zb0001--
@@ -1207,6 +1242,13 @@ func (z *HealOpts) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 			}
 			zb0001Mask |= 0x2
+		case "prefix":
+			z.Prefix, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Prefix")
+				return
+			}
+			zb0001Mask |= 0x4
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1216,13 +1258,16 @@ func (z *HealOpts) UnmarshalMsg(bts []byte) (o []byte, err error) {
 		}
 	}
 	// Clear omitted fields.
-	if zb0001Mask != 0x3 {
+	if zb0001Mask != 0x7 {
 		if (zb0001Mask & 0x1) == 0 {
 			z.Pool = nil
 		}
 		if (zb0001Mask & 0x2) == 0 {
 			z.Set = nil
 		}
+		if (zb0001Mask & 0x4) == 0 {
+			z.Prefix = ""
+		}
 	}
 	o = bts
 	return
@@ -1242,6 +1287,7 @@ func (z *HealOpts) Msgsize() (s int) {
 	} else {
 		s += msgp.IntSize
 	}
+	s += 7 + msgp.StringPrefixSize + len(z.Prefix)
 	return
 }
 
@@ -20,7 +20,11 @@
 package madmin
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
+	"time"
 )
 
 // Tests heal drives missing and offline counts.
@@ -73,3 +77,120 @@ func TestHealDriveCounts(t *testing.T) {
 		t.Errorf("Expected '4', got %d after missing disks", i)
 	}
 }
+
+func TestHealPath(t *testing.T) {
+	tests := []struct {
+		bucket, prefix string
+		opts           HealOpts
+		wantPath       string
+		wantBody       string
+		wantErr        bool
+	}{
+		{
+			bucket:   "bucket",
+			wantPath: "/heal/bucket",
+			wantBody: `{"recursive":false,"dryRun":false,"remove":false,"recreate":false,"scanMode":0,"updateParity":false,"nolock":false}`,
+		},
+		{
+			bucket:   "bucket",
+			prefix:   "dir/object",
+			wantPath: "/heal/bucket/dir/object",
+			wantBody: `{"recursive":false,"dryRun":false,"remove":false,"recreate":false,"scanMode":0,"updateParity":false,"nolock":false}`,
+		},
+		{
+			bucket:   "bucket",
+			opts:     HealOpts{Prefix: "dir/"},
+			wantPath: "/heal/bucket/dir/",
+			wantBody: `{"recursive":false,"dryRun":false,"remove":false,"recreate":false,"scanMode":0,"updateParity":false,"nolock":false,"prefix":"dir/"}`,
+		},
+		{
+			bucket:   "bucket",
+			opts:     HealOpts{Prefix: "dir/", Recursive: true},
+			wantPath: "/heal/bucket/dir/",
+			wantBody: `{"recursive":true,"dryRun":false,"remove":false,"recreate":false,"scanMode":0,"updateParity":false,"nolock":false,"prefix":"dir/"}`,
+		},
+		{
+			bucket:   "bucket",
+			prefix:   "dir/",
+			opts:     HealOpts{Prefix: "dir/", Recursive: true},
+			wantPath: "/heal/bucket/dir/",
+			wantBody: `{"recursive":true,"dryRun":false,"remove":false,"recreate":false,"scanMode":0,"updateParity":false,"nolock":false,"prefix":"dir/"}`,
+		},
+		{
+			bucket:  "bucket",
+			prefix:  "other/",
+			opts:    HealOpts{Prefix: "dir/"},
+			wantErr: true,
+		},
+		{
+			opts:    HealOpts{Prefix: "dir/", Recursive: true},
+			wantErr: true,
+		},
+	}
+	for i, test := range tests {
+		path, err := healPath(test.bucket, test.prefix, test.opts)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("test %d: unexpected error %v", i, err)
+		}
+		if test.wantErr {
+			continue
+		}
+		if path != adminAPIPrefix+test.wantPath {
+			t.Errorf("test %d: expected path %q, got %q", i, test.wantPath, path)
+		}
+		body, err := json.Marshal(test.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != test.wantBody {
+			t.Errorf("test %d: expected body %s, got %s", i, test.wantBody, body)
+		}
+	}
+}
+
+func TestHealWatch(t *testing.T) {
+	defer func(d time.Duration) { healWatchInterval = d }(healWatchInterval)
+	healWatchInterval = time.Millisecond
+
+	statuses := []HealTaskStatus{
+		{Summary: "running", Items: []HealResultItem{{ResultIndex: 1, Type: HealItemBucket, Bucket: "bucket"}}},
+		{Summary: "running"},
+		{Summary: "running", Items: []HealResultItem{{ResultIndex: 2, Type: HealItemObject, Bucket: "bucket", Object: "object"}}},
+		{Summary: "finished"},
+	}
+	polls := 0
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/heal/bucket/dir/" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		token := r.URL.Query().Get("clientToken")
+		if token == "" {
+			json.NewEncoder(w).Encode(HealStartSuccess{ClientToken: "token"})
+			return
+		}
+		if token != "token" {
+			t.Errorf("unexpected client token %q", token)
+		}
+		if polls >= len(statuses) {
+			t.Error("polled after the heal sequence finished")
+			return
+		}
+		json.NewEncoder(w).Encode(statuses[polls])
+		polls++
+	})
+
+	statusCh, errCh := adm.HealWatch(context.Background(), "bucket", "dir/", HealOpts{Recursive: true})
+	var got []HealTaskStatus
+	for status := range statusCh {
+		got = append(got, status)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 statuses, got %d: %+v", len(got), got)
+	}
+	if got[0].Items[0].ResultIndex != 1 || got[1].Items[0].ResultIndex != 2 || got[2].Summary != "finished" {
+		t.Errorf("unexpected statuses %+v", got)
+	}
+}
@@ -0,0 +1,173 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config keys of the heal sub-system.
+const (
+	HealBitrotScanKey   = "bitrotscan"
+	HealMaxSleepKey     = "max_sleep"
+	HealMaxIOKey        = "max_io"
+	HealDriveWorkersKey = "drive_workers"
+)
+
+// HealSpeed holds the throttling parameters of the background healer.
+type HealSpeed struct {
+	// MaxSleep is the maximum time the healer waits between heal operations
+	// when the server is busy. Zero keeps the server default.
+	MaxSleep time.Duration `json:"maxSleep"`
+
+	// MaxIO is the number of concurrent IO operations above which the healer
+	// starts to wait between heal operations. Zero keeps the server default.
+	MaxIO int `json:"maxIO"`
+}
+
+// Validate returns an error if the heal speed parameters are out of range.
+func (s HealSpeed) Validate() error {
+	if s.MaxSleep < 0 {
+		return errors.New("heal max_sleep must not be negative")
+	}
+	if s.MaxIO < 0 {
+		return errors.New("heal max_io must not be negative")
+	}
+	return nil
+}
+
+func (s HealSpeed) kvs() []string {
+	return []string{
+		HealMaxSleepKey + KvSeparator + formatDuration(s.MaxSleep),
+		HealMaxIOKey + KvSeparator + formatInt(s.MaxIO),
+	}
+}
+
+func parseHealSpeed(cfg SubsysConfig) (s HealSpeed, err error) {
+	if v, ok := cfg.Lookup(HealMaxSleepKey); ok && v != "" {
+		s.MaxSleep, err = time.ParseDuration(v)
+		if err != nil {
+			return s, fmt.Errorf("invalid heal %s value %q: %w", HealMaxSleepKey, v, err)
+		}
+	}
+	if v, ok := cfg.Lookup(HealMaxIOKey); ok && v != "" {
+		s.MaxIO, err = strconv.Atoi(v)
+		if err != nil {
+			return s, fmt.Errorf("invalid heal %s value %q: %w", HealMaxIOKey, v, err)
+		}
+	}
+	return s, nil
+}
+
+// GetHealSpeed - returns the throttling parameters of the background healer.
+func (adm *AdminClient) GetHealSpeed(ctx context.Context) (HealSpeed, error) {
+	cfg, err := adm.getSubsysConfig(ctx, HealSubSys)
+	if err != nil {
+		return HealSpeed{}, err
+	}
+	return parseHealSpeed(cfg)
+}
+
+// SetHealSpeed - sets the throttling parameters of the background healer.
+func (adm *AdminClient) SetHealSpeed(ctx context.Context, s HealSpeed) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	return adm.setSubsysConfig(ctx, HealSubSys, s.kvs()...)
+}
+
+// HealConfig holds the configuration of the background healer.
+type HealConfig struct {
+	// BitrotScan enables verifying the bitrot checksums of objects
+	// during scanning. Either "on", "off" or a cycle length in months
+	// such as "12m".
+	BitrotScan string `json:"bitrotScan"`
+
+	HealSpeed
+
+	// DriveWorkers is the number of workers healing a fresh drive.
+	// Zero lets the server choose.
+	DriveWorkers int `json:"driveWorkers"`
+}
+
+// Validate returns an error if the heal configuration is invalid.
+func (c HealConfig) Validate() error {
+	if err := validateBitrotScan(c.BitrotScan); err != nil {
+		return err
+	}
+	if err := c.HealSpeed.Validate(); err != nil {
+		return err
+	}
+	if c.DriveWorkers < 0 {
+		return errors.New("heal drive_workers must not be negative")
+	}
+	return nil
+}
+
+func validateBitrotScan(v string) error {
+	switch v {
+	case EnableOn, EnableOff:
+		return nil
+	}
+	months, ok := strings.CutSuffix(v, "m")
+	if n, err := strconv.Atoi(months); !ok || err != nil || n <= 0 {
+		return fmt.Errorf("invalid heal %s value %q: must be on, off or a number of months like 12m", HealBitrotScanKey, v)
+	}
+	return nil
+}
+
+func (c HealConfig) kvs() []string {
+	kvs := []string{HealBitrotScanKey + KvSeparator + c.BitrotScan}
+	kvs = append(kvs, c.HealSpeed.kvs()...)
+	return append(kvs, HealDriveWorkersKey+KvSeparator+formatInt(c.DriveWorkers))
+}
+
+func parseHealConfig(cfg SubsysConfig) (c HealConfig, err error) {
+	c.BitrotScan, _ = cfg.Lookup(HealBitrotScanKey)
+	if c.HealSpeed, err = parseHealSpeed(cfg); err != nil {
+		return c, err
+	}
+	if c.DriveWorkers, err = lookupInt(cfg, HealDriveWorkersKey); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// GetHealConfig - returns the configuration of the background healer.
+func (adm *AdminClient) GetHealConfig(ctx context.Context) (HealConfig, error) {
+	cfg, err := adm.getSubsysConfig(ctx, HealSubSys)
+	if err != nil {
+		return HealConfig{}, err
+	}
+	return parseHealConfig(cfg)
+}
+
+// SetHealConfig - sets the configuration of the background healer.
+func (adm *AdminClient) SetHealConfig(ctx context.Context, c HealConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	return adm.setSubsysConfig(ctx, HealSubSys, c.kvs()...)
+}
@@ -0,0 +1,151 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealSpeedRoundTrip(t *testing.T) {
+	tests := []HealSpeed{
+		{MaxSleep: 250 * time.Millisecond, MaxIO: 100},
+		{MaxSleep: time.Second, MaxIO: 1},
+		{MaxSleep: 0, MaxIO: 10000},
+		{},
+	}
+	for _, want := range tests {
+		if err := want.Validate(); err != nil {
+			t.Fatalf("%+v: unexpected validation error: %v", want, err)
+		}
+		cfgs, err := ParseServerConfigOutput(HealSubSys + " " + strings.Join(want.kvs(), " "))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parseHealSpeed(cfgs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestHealSpeedValidate(t *testing.T) {
+	tests := []HealSpeed{
+		{MaxSleep: -time.Second, MaxIO: 100},
+		{MaxSleep: time.Second, MaxIO: -1},
+	}
+	for _, s := range tests {
+		if err := s.Validate(); err == nil {
+			t.Errorf("%+v: expected validation error", s)
+		}
+	}
+}
+
+func TestHealSpeedKVsZero(t *testing.T) {
+	got := strings.Join(HealSpeed{}.kvs(), " ")
+	if want := "max_sleep= max_io="; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestParseHealSpeedEnvOverride(t *testing.T) {
+	cfgs, err := ParseServerConfigOutput("# MINIO_HEAL_MAX_IO=20\nheal bitrotscan=off max_sleep=1s max_io=100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := parseHealSpeed(cfgs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := HealSpeed{MaxSleep: time.Second, MaxIO: 20}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestHealConfigRoundTrip(t *testing.T) {
+	tests := []HealConfig{
+		{BitrotScan: "off", HealSpeed: HealSpeed{MaxSleep: 250 * time.Millisecond, MaxIO: 100}},
+		{BitrotScan: "on", HealSpeed: HealSpeed{MaxSleep: time.Second, MaxIO: 10}, DriveWorkers: 4},
+		{BitrotScan: "12m", HealSpeed: HealSpeed{MaxSleep: 0, MaxIO: 1}},
+	}
+	for _, want := range tests {
+		if err := want.Validate(); err != nil {
+			t.Fatalf("%+v: unexpected validation error: %v", want, err)
+		}
+		cfgs, err := ParseServerConfigOutput(HealSubSys + " " + strings.Join(want.kvs(), " "))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parseHealConfig(cfgs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestHealConfigValidate(t *testing.T) {
+	valid := HealSpeed{MaxSleep: time.Second, MaxIO: 100}
+	tests := []HealConfig{
+		{BitrotScan: "", HealSpeed: valid},
+		{BitrotScan: "yes", HealSpeed: valid},
+		{BitrotScan: "0m", HealSpeed: valid},
+		{BitrotScan: "m", HealSpeed: valid},
+		{BitrotScan: "on", HealSpeed: HealSpeed{MaxIO: -1}},
+		{BitrotScan: "on", HealSpeed: valid, DriveWorkers: -1},
+	}
+	for _, c := range tests {
+		if err := c.Validate(); err == nil {
+			t.Errorf("%+v: expected validation error", c)
+		}
+	}
+}
+
+func TestGetSetHealConfig(t *testing.T) {
+	adm, received := newTestConfigKVClient(t, HealSubSys, HealSubSys+" bitrotscan=on max_sleep=1s max_io=100 drive_workers=4")
+	got, err := adm.GetHealConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := HealConfig{BitrotScan: "on", HealSpeed: HealSpeed{MaxSleep: time.Second, MaxIO: 100}, DriveWorkers: 4}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if err = adm.SetHealConfig(context.Background(), HealConfig{BitrotScan: "off", HealSpeed: HealSpeed{MaxSleep: 250 * time.Millisecond}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := HealSubSys + " bitrotscan=off max_sleep=250ms max_io= drive_workers="; *received != want {
+		t.Errorf("expected %q, got %q", want, *received)
+	}
+	if err = adm.SetHealSpeed(context.Background(), HealSpeed{MaxIO: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if want := HealSubSys + " max_sleep= max_io=10"; *received != want {
+		t.Errorf("expected %q, got %q", want, *received)
+	}
+}
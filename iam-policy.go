@@ -0,0 +1,103 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PolicyValues is a list of actions or resources of a policy statement,
+// given either as a single string or as an array of strings.
+type PolicyValues []string
+
+// UnmarshalJSON decodes a single string or an array of strings.
+func (v *PolicyValues) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = PolicyValues{s}
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return errors.New("expected a string or an array of strings")
+	}
+	*v = values
+	return nil
+}
+
+// PolicyStatement is a statement of an IAM policy document.
+type PolicyStatement struct {
+	SID         string          `json:"Sid,omitempty"`
+	Effect      string          `json:"Effect"`
+	Principal   json.RawMessage `json:"Principal,omitempty"`
+	Action      PolicyValues    `json:"Action,omitempty"`
+	NotAction   PolicyValues    `json:"NotAction,omitempty"`
+	Resource    PolicyValues    `json:"Resource,omitempty"`
+	NotResource PolicyValues    `json:"NotResource,omitempty"`
+	Condition   json.RawMessage `json:"Condition,omitempty"`
+}
+
+// ParsePolicyStatements parses the statements of an IAM policy document,
+// returning a detailed error if the policy is malformed. Only the structure
+// of the policy is checked, actions, resources and conditions are verified
+// by the server.
+func ParsePolicyStatements(policy []byte) ([]PolicyStatement, error) {
+	var doc struct {
+		Version   string
+		Statement []PolicyStatement
+	}
+	dec := json.NewDecoder(bytes.NewReader(policy))
+	if err := dec.Decode(&doc); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return nil, fmt.Errorf("invalid policy: %w at offset %d", err, syntaxErr.Offset)
+		}
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+	if dec.More() {
+		return nil, errors.New("invalid policy: unexpected data after the policy document")
+	}
+	if len(doc.Statement) == 0 {
+		return nil, errors.New("invalid policy: no statements")
+	}
+	for i, st := range doc.Statement {
+		if st.Effect != "Allow" && st.Effect != "Deny" {
+			return nil, fmt.Errorf("invalid policy statement %d: effect must be Allow or Deny, got %q", i, st.Effect)
+		}
+		if len(st.Action) == 0 && len(st.NotAction) == 0 {
+			return nil, fmt.Errorf("invalid policy statement %d: no actions", i)
+		}
+	}
+	return doc.Statement, nil
+}
+
+// ValidateInlinePolicy returns a detailed error if the inline session policy
+// of a service account is malformed. An empty policy is valid and makes the
+// service account inherit the policies of its parent user.
+func ValidateInlinePolicy(policy []byte) error {
+	if len(bytes.TrimSpace(policy)) == 0 {
+		return nil
+	}
+	_, err := ParsePolicyStatements(policy)
+	return err
+}
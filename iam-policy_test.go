@@ -0,0 +1,173 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateInlinePolicy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{name: "empty", policy: ""},
+		{
+			name:   "valid",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"],"Resource":["arn:aws:s3:::bucket/*"]}]}`,
+		},
+		{
+			name:   "single action and resource",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":"s3:DeleteObject","Resource":"arn:aws:s3:::bucket/*"}]}`,
+		},
+		{
+			name:    "syntax error",
+			policy:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow",}]}`,
+			wantErr: "invalid policy: invalid character '}' looking for beginning of object key string at offset 56",
+		},
+		{
+			name:    "truncated",
+			policy:  `{"Version":"2012-10-17","Statement":[`,
+			wantErr: "invalid policy: unexpected EOF",
+		},
+		{
+			name:    "statement not a list",
+			policy:  `{"Version":"2012-10-17","Statement":"s3:*"}`,
+			wantErr: "invalid policy: json: cannot unmarshal string into Go struct field .Statement",
+		},
+		{
+			name:    "no statements",
+			policy:  `{"Version":"2012-10-17","Statement":[]}`,
+			wantErr: "invalid policy: no statements",
+		},
+		{
+			name:    "invalid effect",
+			policy:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*"},{"Effect":"allow","Action":"s3:*"}]}`,
+			wantErr: `invalid policy statement 1: effect must be Allow or Deny, got "allow"`,
+		},
+		{
+			name:    "no actions",
+			policy:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Resource":"arn:aws:s3:::*"}]}`,
+			wantErr: "invalid policy statement 0: no actions",
+		},
+		{
+			name:    "invalid action",
+			policy:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":1}]}`,
+			wantErr: "invalid policy: expected a string or an array of strings",
+		},
+		{
+			name:    "trailing data",
+			policy:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*"}]}{}`,
+			wantErr: "invalid policy: unexpected data after the policy document",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateInlinePolicy([]byte(tc.policy))
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("expected no error, got %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.wantErr)):
+				t.Errorf("expected error %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParsePolicyStatements(t *testing.T) {
+	statements, err := ParsePolicyStatements([]byte(`{"Version":"2012-10-17","Statement":[
+		{"Sid":"read","Effect":"Allow","Action":"s3:GetObject","Resource":["arn:aws:s3:::a/*","arn:aws:s3:::b/*"]},
+		{"Effect":"Deny","NotAction":["s3:ListBucket"],"Resource":"arn:aws:s3:::c","Condition":{"Bool":{"aws:SecureTransport":"false"}}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PolicyStatement{
+		{SID: "read", Effect: "Allow", Action: PolicyValues{"s3:GetObject"}, Resource: PolicyValues{"arn:aws:s3:::a/*", "arn:aws:s3:::b/*"}},
+		{Effect: "Deny", NotAction: PolicyValues{"s3:ListBucket"}, Resource: PolicyValues{"arn:aws:s3:::c"}, Condition: []byte(`{"Bool":{"aws:SecureTransport":"false"}}`)},
+	}
+	if !reflect.DeepEqual(statements, want) {
+		t.Errorf("expected %+v, got %+v", want, statements)
+	}
+}
+
+func TestAddServiceAccountInvalidPolicy(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request for an invalid policy %s", r.URL)
+	})
+	_, err := adm.AddServiceAccount(context.Background(), AddServiceAccountReq{
+		Policy: []byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow"}]}`),
+	})
+	if err == nil || err.Error() != "invalid policy statement 0: no actions" {
+		t.Errorf("expected invalid policy error, got %v", err)
+	}
+}
+
+func TestInfoServiceAccountPolicyStatements(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/info-service-account" || r.URL.Query().Get("accessKey") != "SVC1" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		data, err := json.Marshal(InfoServiceAccountResp{
+			ParentUser:    "alice",
+			AccountStatus: "on",
+			Policy:        `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::bucket/*"]}]}`,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		edata, err := EncryptData("minioadmin", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(edata)
+	})
+
+	info, err := adm.InfoServiceAccount(context.Background(), "SVC1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	statements, err := info.PolicyStatements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PolicyStatement{{Effect: "Allow", Action: PolicyValues{"s3:GetObject"}, Resource: PolicyValues{"arn:aws:s3:::bucket/*"}}}
+	if !reflect.DeepEqual(statements, want) {
+		t.Errorf("expected %+v, got %+v", want, statements)
+	}
+
+	if statements, err = (InfoServiceAccountResp{}).PolicyStatements(); statements != nil || err != nil {
+		t.Errorf("expected no statements without a policy, got %+v, %v", statements, err)
+	}
+	if _, err = (InfoServiceAccountResp{Policy: "{"}).PolicyStatements(); err == nil {
+		t.Error("expected an error for a malformed policy")
+	}
+
+	pi := PolicyInfo{PolicyName: "readonly", Policy: []byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::*"}]}`)}
+	if statements, err = pi.Statements(); err != nil || len(statements) != 1 || statements[0].Action[0] != "s3:GetObject" {
+		t.Errorf("unexpected canned policy statements %+v, %v", statements, err)
+	}
+}
@@ -174,6 +174,42 @@ func (adm *AdminClient) GetIDPConfig(ctx context.Context, cfgType, cfgName strin
 	return c, err
 }
 
+// OpenIDInfo contains the claim based policy mapping settings of the default
+// OpenID provider.
+type OpenIDInfo struct {
+	// ConfigURL is the discovery URL of the provider.
+	ConfigURL string `json:"configURL"`
+	// ClaimName is the JWT claim that holds the policies of a user.
+	ClaimName string `json:"claimName"`
+	// RolePolicy is set instead of ClaimName if the provider is role based.
+	RolePolicy string `json:"rolePolicy,omitempty"`
+}
+
+// OpenIDClaimInfo - returns the configured claim name, role policy and
+// provider of the default OpenID configuration.
+func (adm *AdminClient) OpenIDClaimInfo(ctx context.Context) (OpenIDInfo, error) {
+	c, err := adm.GetIDPConfig(ctx, OpenidIDPCfg, "")
+	if err != nil {
+		return OpenIDInfo{}, err
+	}
+	return c.openIDInfo(), nil
+}
+
+func (c IDPConfig) openIDInfo() OpenIDInfo {
+	var info OpenIDInfo
+	for _, kv := range c.Info {
+		switch kv.Key {
+		case "config_url":
+			info.ConfigURL = kv.Value
+		case "claim_name":
+			info.ClaimName = kv.Value
+		case "role_policy":
+			info.RolePolicy = kv.Value
+		}
+	}
+	return info
+}
+
 // IDPListItem - represents an item in the List IDPs call.
 type IDPListItem struct {
 	Type    string `json:"type"`
@@ -343,6 +379,33 @@ func (adm *AdminClient) GetLDAPPolicyEntities(ctx context.Context,
 	return r, err
 }
 
+// LDAPEntities - LDAP users and groups (as DNs) attached to each policy,
+// keyed by policy name.
+type LDAPEntities map[string]PolicyEntities
+
+// LDAPPolicyEntities - returns the LDAP users and groups attached to each of
+// the given policies. All policies with LDAP attachments are returned if
+// policies is empty.
+func (adm *AdminClient) LDAPPolicyEntities(ctx context.Context, policies []string) (LDAPEntities, error) {
+	r, err := adm.GetLDAPPolicyEntities(ctx, PolicyEntitiesQuery{Policy: policies})
+	if err != nil {
+		return nil, err
+	}
+	return r.ldapEntities(), nil
+}
+
+func (r PolicyEntitiesResult) ldapEntities() LDAPEntities {
+	entities := make(LDAPEntities, len(r.PolicyMappings))
+	for _, m := range r.PolicyMappings {
+		e := entities[m.Policy]
+		e.Policy = m.Policy
+		e.Users = append(e.Users, m.Users...)
+		e.Groups = append(e.Groups, m.Groups...)
+		entities[m.Policy] = e
+	}
+	return entities
+}
+
 // PolicyAssociationResp - result of a policy association request.
 type PolicyAssociationResp struct {
 	PoliciesAttached []string `json:"policiesAttached,omitempty"`
@@ -383,19 +446,30 @@ func (p PolicyAssociationReq) IsValid() error {
 	return nil
 }
 
-// AttachPolicyLDAP - client call to attach policies for LDAP.
-func (adm *AdminClient) AttachPolicyLDAP(ctx context.Context, par PolicyAssociationReq) (PolicyAssociationResp, error) {
+// LDAPPolicyAssociation - request to attach/detach policies from/to an LDAP
+// user or group. User and Group are LDAP DNs (or usernames, which the server
+// resolves to DNs), exactly one of them must be set.
+type LDAPPolicyAssociation = PolicyAssociationReq
+
+// AttachPolicyLDAP - client call to attach policies for LDAP. The response
+// lists the policies that were newly attached.
+func (adm *AdminClient) AttachPolicyLDAP(ctx context.Context, par LDAPPolicyAssociation) (PolicyAssociationResp, error) {
 	return adm.attachOrDetachPolicyLDAP(ctx, true, par)
 }
 
-// DetachPolicyLDAP - client call to detach policies for LDAP.
-func (adm *AdminClient) DetachPolicyLDAP(ctx context.Context, par PolicyAssociationReq) (PolicyAssociationResp, error) {
+// DetachPolicyLDAP - client call to detach policies for LDAP. The response
+// lists the policies that were detached.
+func (adm *AdminClient) DetachPolicyLDAP(ctx context.Context, par LDAPPolicyAssociation) (PolicyAssociationResp, error) {
 	return adm.attachOrDetachPolicyLDAP(ctx, false, par)
 }
 
 func (adm *AdminClient) attachOrDetachPolicyLDAP(ctx context.Context, isAttach bool,
-	par PolicyAssociationReq,
+	par LDAPPolicyAssociation,
 ) (PolicyAssociationResp, error) {
+	if err := par.IsValid(); err != nil {
+		return PolicyAssociationResp{}, err
+	}
+
 	plainBytes, err := json.Marshal(par)
 	if err != nil {
 		return PolicyAssociationResp{}, err
@@ -0,0 +1,157 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLDAPPolicyEntities(t *testing.T) {
+	const groupDN = "cn=project,ou=groups,ou=swengg,dc=min,dc=io"
+	const userDN = "uid=dillon,ou=people,ou=swengg,dc=min,dc=io"
+
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/idp/ldap/policy-entities" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.URL.Query()["policy"]; !reflect.DeepEqual(got, []string{"readwrite"}) {
+			t.Errorf("unexpected policy query %q", got)
+		}
+		data, err := EncryptData("minioadmin", []byte(`{
+			"timestamp": "2024-05-01T10:00:00Z",
+			"policyMappings": [{
+				"policy": "readwrite",
+				"users": ["`+userDN+`"],
+				"groups": ["`+groupDN+`"]
+			}]
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	})
+
+	entities, err := adm.LDAPPolicyEntities(context.Background(), []string{"readwrite"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := LDAPEntities{
+		"readwrite": {
+			Policy: "readwrite",
+			Users:  []string{userDN},
+			Groups: []string{groupDN},
+		},
+	}
+	if !reflect.DeepEqual(entities, want) {
+		t.Errorf("expected %+v, got %+v", want, entities)
+	}
+}
+
+func TestAttachPolicyLDAP(t *testing.T) {
+	const userDN = "uid=dillon,ou=people,ou=swengg,dc=min,dc=io"
+	updatedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/idp/ldap/policy/attach" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		body, err := DecryptData("minioadmin", r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var req LDAPPolicyAssociation
+		if err = json.Unmarshal(body, &req); err != nil {
+			t.Fatal(err)
+		}
+		if req.User != userDN || req.Group != "" {
+			t.Errorf("unexpected association %+v", req)
+		}
+		data, err := json.Marshal(PolicyAssociationResp{
+			PoliciesAttached: req.Policies[:1],
+			UpdatedAt:        updatedAt,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		edata, err := EncryptData("minioadmin", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(edata)
+	})
+
+	ctx := context.Background()
+	resp, err := adm.AttachPolicyLDAP(ctx, LDAPPolicyAssociation{
+		Policies: []string{"readwrite", "diagnostics"},
+		User:     userDN,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp.PoliciesAttached, []string{"readwrite"}) || !resp.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	if _, err = adm.AttachPolicyLDAP(ctx, LDAPPolicyAssociation{Policies: []string{"readwrite"}}); err == nil {
+		t.Error("expected an error for an association without user or group")
+	}
+}
+
+func TestOpenIDClaimInfo(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/idp-config/openid/_" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		data, err := json.Marshal(IDPConfig{
+			Type: OpenidIDPCfg,
+			Info: []IDPCfgInfo{
+				{Key: "config_url", Value: "https://accounts.google.com/.well-known/openid-configuration", IsCfg: true},
+				{Key: "client_id", Value: "minio-client", IsCfg: true},
+				{Key: "claim_name", Value: "groups", IsCfg: true, IsEnv: true},
+				{Key: "scopes", Value: "openid,groups", IsCfg: true},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		edata, err := EncryptData("minioadmin", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(edata)
+	})
+
+	info, err := adm.OpenIDClaimInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := OpenIDInfo{
+		ConfigURL: "https://accounts.google.com/.well-known/openid-configuration",
+		ClaimName: "groups",
+	}
+	if info != want {
+		t.Errorf("expected %+v, got %+v", want, info)
+	}
+}
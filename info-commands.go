@@ -22,9 +22,12 @@ package madmin
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -217,6 +220,39 @@ type DataUsageInfo struct {
 	TotalUsedCapacity uint64 `json:"usedCapacity"`
 }
 
+//msgp:ignore VersionStats
+
+// VersionStats holds the object version stats of a bucket.
+type VersionStats struct {
+	Objects       uint64 `json:"objects"`
+	Versions      uint64 `json:"versions"`
+	DeleteMarkers uint64 `json:"deleteMarkers"`
+}
+
+// BucketVersionStats returns the object version stats of bucket,
+// false is returned if there is no usage info for the bucket.
+func (d DataUsageInfo) BucketVersionStats(bucket string) (VersionStats, bool) {
+	u, ok := d.BucketsUsage[bucket]
+	if !ok {
+		return VersionStats{}, false
+	}
+	return VersionStats{
+		Objects:       u.ObjectsCount,
+		Versions:      u.VersionsCount,
+		DeleteMarkers: u.DeleteMarkersCount,
+	}, true
+}
+
+// VersionCount returns the number of object versions in bucket.
+func (d DataUsageInfo) VersionCount(bucket string) uint64 {
+	return d.BucketsUsage[bucket].VersionsCount
+}
+
+// DeleteMarkerCount returns the number of delete markers in bucket.
+func (d DataUsageInfo) DeleteMarkerCount(bucket string) uint64 {
+	return d.BucketsUsage[bucket].DeleteMarkersCount
+}
+
 // DataUsageInfo - returns data usage of the current object API
 func (adm *AdminClient) DataUsageInfo(ctx context.Context) (DataUsageInfo, error) {
 	values := make(url.Values)
@@ -245,6 +281,157 @@ func (adm *AdminClient) DataUsageInfo(ctx context.Context) (DataUsageInfo, error
 	return dataUsageInfo, nil
 }
 
+//msgp:ignore BucketStat
+
+// BucketStat is the usage and feature overview of a single bucket.
+// Whether a bucket has a lifecycle configuration is not exposed by
+// the admin API, so it is not part of the overview.
+type BucketStat struct {
+	Name                   string    `json:"name"`
+	Created                time.Time `json:"created"`
+	Size                   uint64    `json:"size"`
+	Objects                uint64    `json:"objects"`
+	Versions               uint64    `json:"versions"`
+	DeleteMarkers          uint64    `json:"deleteMarkers"`
+	Versioning             bool      `json:"versioning"`
+	VersioningSuspended    bool      `json:"versioningSuspended"`
+	Locking                bool      `json:"locking"`
+	Replication            bool      `json:"replication"`
+	ReplicationPendingSize uint64    `json:"replicationPendingSize"`
+	ReplicationFailedSize  uint64    `json:"replicationFailedSize"`
+}
+
+// ClusterBucketStats - returns the usage and feature flags of every bucket
+// in the cluster, sorted by bucket name. The buckets and their flags come
+// from the account info, their usage from the data usage of the cluster.
+// Buckets the scanner has not reported on yet only have the usage known
+// to the account info.
+func (adm *AdminClient) ClusterBucketStats(ctx context.Context) ([]BucketStat, error) {
+	accInfo, err := adm.AccountInfo(ctx, AccountOpts{})
+	if err != nil {
+		return nil, err
+	}
+	usage, err := adm.DataUsageInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]BucketStat, 0, len(accInfo.Buckets))
+	for _, b := range accInfo.Buckets {
+		st := BucketStat{
+			Name:    b.Name,
+			Created: b.Created,
+			Size:    b.Size,
+			Objects: b.Objects,
+		}
+		if d := b.Details; d != nil {
+			st.Versioning = d.Versioning
+			st.VersioningSuspended = d.VersioningSuspended
+			st.Locking = d.Locking
+			st.Replication = d.Replication
+		}
+		if u, ok := usage.BucketsUsage[b.Name]; ok {
+			st.Size = u.Size
+			st.Objects = u.ObjectsCount
+			st.Versions = u.VersionsCount
+			st.DeleteMarkers = u.DeleteMarkersCount
+			st.ReplicationPendingSize = u.ReplicationPendingSize
+			st.ReplicationFailedSize = u.ReplicationFailedSize
+		}
+		stats = append(stats, st)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+	return stats, nil
+}
+
+// DataUsageInfoStream - streams the usage of every bucket to onBucket,
+// without holding the full data usage of the cluster in memory.
+// Decoding stops at the first error returned by onBucket.
+func (adm *AdminClient) DataUsageInfoStream(ctx context.Context, onBucket func(bucket string, u BucketUsageInfo) error) error {
+	values := make(url.Values)
+	values.Set("capacity", "true")
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		relPath:     adminAPIPrefix + "/datausageinfo",
+		queryValues: values,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return decodeBucketsUsage(json.NewDecoder(resp.Body), onBucket)
+}
+
+// decodeBucketsUsage decodes the buckets usage of a DataUsageInfo
+// one bucket at a time, skipping all other fields.
+func decodeBucketsUsage(dec *json.Decoder, onBucket func(bucket string, u BucketUsageInfo) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := tok.(string); key != "bucketsUsageInfo" {
+			var skip json.RawMessage
+			if err = dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			continue
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '{' {
+			return fmt.Errorf("unexpected token %v in bucketsUsageInfo", tok)
+		}
+		for dec.More() {
+			tok, err = dec.Token()
+			if err != nil {
+				return err
+			}
+			bucket, _ := tok.(string)
+			var u BucketUsageInfo
+			if err = dec.Decode(&u); err != nil {
+				return err
+			}
+			if err = onBucket(bucket, u); err != nil {
+				return err
+			}
+		}
+		if err = expectDelim(dec, '}'); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+// expectDelim reads the next token of dec and checks it is delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+	return nil
+}
+
 // ErasureSetInfo provides information per erasure set
 type ErasureSetInfo struct {
 	ID                 int    `json:"id"`
@@ -297,6 +484,98 @@ func (info InfoMessage) StandardParity() int {
 	}
 }
 
+// ClusterHealthSummary contains the number of online and offline servers
+// and drives of a cluster and its capacity.
+//
+//msgp:ignore ClusterHealthSummary
+type ClusterHealthSummary struct {
+	OnlineServers  int `json:"onlineServers"`
+	OfflineServers int `json:"offlineServers"` // Includes initializing servers.
+
+	OnlineDrives  int `json:"onlineDrives"`
+	OfflineDrives int `json:"offlineDrives"` // Includes drives in any state other than ok.
+	HealingDrives int `json:"healingDrives"` // Online drives that are healing.
+
+	// RawCapacity is the total space of all online drives, UsableCapacity
+	// the part of it that is left for data with the standard parity.
+	RawCapacity    uint64 `json:"rawCapacity"`
+	UsableCapacity uint64 `json:"usableCapacity"`
+}
+
+// HealthSummary returns the number of online and offline servers and
+// drives in info and the capacity of the online drives.
+func (info InfoMessage) HealthSummary() ClusterHealthSummary {
+	var sum ClusterHealthSummary
+	parity := info.StandardParity()
+	for _, srv := range info.Servers {
+		if ItemState(srv.State) == ItemOnline {
+			sum.OnlineServers++
+		} else {
+			sum.OfflineServers++
+		}
+		for _, d := range srv.Disks {
+			if d.State != DriveStateOk {
+				sum.OfflineDrives++
+				continue
+			}
+			sum.OnlineDrives++
+			if d.Healing {
+				sum.HealingDrives++
+			}
+			sum.RawCapacity += d.TotalSpace
+
+			usable := d.TotalSpace
+			if d.PoolIndex >= 0 && d.PoolIndex < len(info.Backend.DrivesPerSet) && parity > 0 {
+				if n := info.Backend.DrivesPerSet[d.PoolIndex]; n > parity {
+					usable = usable / uint64(n) * uint64(n-parity)
+				}
+			}
+			sum.UsableCapacity += usable
+		}
+	}
+	return sum
+}
+
+//msgp:ignore StartupError
+
+// StartupError is a node that is not online, or a drive of an online node
+// that could not be initialized, e.g. due to missing permissions.
+type StartupError struct {
+	Node string `json:"node"`
+	// Drive is the drive path, empty for nodes.
+	Drive string `json:"drive,omitempty"`
+	// State is the state of the node or drive, e.g. DriveStatePermission.
+	State string `json:"state"`
+}
+
+// StartupErrors - returns the nodes that are not online and the drives of
+// online nodes that are not ok, from fresh server info. Failures of nodes that
+// did not start at all, such as a misconfiguration or a port in use, are not
+// reported by the server and the node is only returned as offline.
+func (adm *AdminClient) StartupErrors(ctx context.Context) ([]StartupError, error) {
+	info, err := adm.ServerInfoRefresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return info.startupErrors(), nil
+}
+
+func (info InfoMessage) startupErrors() []StartupError {
+	var errs []StartupError
+	for _, srv := range info.Servers {
+		if ItemState(srv.State) != ItemOnline {
+			errs = append(errs, StartupError{Node: srv.Endpoint, State: srv.State})
+			continue
+		}
+		for _, d := range srv.Disks {
+			if d.State != DriveStateOk {
+				errs = append(errs, StartupError{Node: srv.Endpoint, Drive: d.DrivePath, State: d.State})
+			}
+		}
+	}
+	return errs
+}
+
 // Services contains different services information
 type Services struct {
 	KMS           KMS                           `json:"kms,omitempty"` // deprecated july 2023
@@ -345,6 +624,19 @@ type TierStats struct {
 	NumObjects  int    `json:"numObjects"`
 }
 
+// Sub returns the change of the stats since prev, such as the data tiered
+// within a time window. A counter below its previous value, after a server
+// restart, gives a change of zero.
+func (ts TierStats) Sub(prev TierStats) TierStats {
+	var d TierStats
+	if ts.TotalSize > prev.TotalSize {
+		d.TotalSize = ts.TotalSize - prev.TotalSize
+	}
+	d.NumVersions = max(ts.NumVersions-prev.NumVersions, 0)
+	d.NumObjects = max(ts.NumObjects-prev.NumObjects, 0)
+	return d
+}
+
 // KMS contains KMS status information
 type KMS struct {
 	Status   string `json:"status,omitempty"`
@@ -484,6 +776,21 @@ type CacheStats struct {
 	Collisions int64 `json:"collisions"`
 }
 
+// Lookups returns the number of cache lookups, hits and misses combined.
+func (c CacheStats) Lookups() int64 {
+	return c.Hits + c.Misses
+}
+
+// HitRatio returns the fraction of cache lookups that were hits,
+// or 0 if there were no lookups.
+func (c CacheStats) HitRatio() float64 {
+	total := c.Lookups()
+	if total <= 0 {
+		return 0
+	}
+	return float64(c.Hits) / float64(total)
+}
+
 // Disk holds Disk information
 type Disk struct {
 	Endpoint        string       `json:"endpoint,omitempty"`
@@ -520,6 +827,14 @@ type Disk struct {
 // ServerInfoOpts ask for additional data from the server
 type ServerInfoOpts struct {
 	Metrics bool
+
+	// NoDrives, NoPools and NoMetrics leave out the drives of every
+	// server, the erasure set information of every pool and the metrics
+	// of every drive. They are removed on the client for servers that
+	// still return them.
+	NoDrives  bool
+	NoPools   bool
+	NoMetrics bool
 }
 
 // WithDriveMetrics asks server to return additional metrics per drive
@@ -529,17 +844,112 @@ func WithDriveMetrics(metrics bool) func(*ServerInfoOpts) {
 	}
 }
 
+// WithoutDrives asks server to leave out the drives of every server
+func WithoutDrives() func(*ServerInfoOpts) {
+	return func(opts *ServerInfoOpts) {
+		opts.NoDrives = true
+	}
+}
+
+// WithoutMetrics asks server to leave out the metrics of every drive,
+// overriding WithDriveMetrics
+func WithoutMetrics() func(*ServerInfoOpts) {
+	return func(opts *ServerInfoOpts) {
+		opts.Metrics = false
+		opts.NoMetrics = true
+	}
+}
+
+// WithoutPools asks server to leave out the erasure sets of every pool
+func WithoutPools() func(*ServerInfoOpts) {
+	return func(opts *ServerInfoOpts) {
+		opts.NoPools = true
+	}
+}
+
 // ServerInfo - Connect to a minio server and call Server Admin Info Management API
 // to fetch server's information represented by infoMessage structure
+// If Options.ServerInfoCacheTTL is set, the result of a previous call with the
+// same options is returned until it expires, the returned InfoMessage is then
+// shared with other callers and must not be modified.
 func (adm *AdminClient) ServerInfo(ctx context.Context, options ...func(*ServerInfoOpts)) (InfoMessage, error) {
-	srvOpts := &ServerInfoOpts{}
+	srvOpts := ServerInfoOpts{}
+	for _, o := range options {
+		o(&srvOpts)
+	}
+	if info, ok := adm.serverInfoCache.get(srvOpts); ok {
+		return info, nil
+	}
+	return adm.ServerInfoRefresh(ctx, options...)
+}
 
+// ServerInfoRefresh - fetches the server's information like ServerInfo, but
+// never returns a cached result. The cache of ServerInfo is updated with it.
+func (adm *AdminClient) ServerInfoRefresh(ctx context.Context, options ...func(*ServerInfoOpts)) (InfoMessage, error) {
+	srvOpts := ServerInfoOpts{}
 	for _, o := range options {
-		o(srvOpts)
+		o(&srvOpts)
 	}
+	info, err := adm.serverInfo(ctx, srvOpts)
+	if err != nil {
+		return info, err
+	}
+	adm.serverInfoCache.set(srvOpts, info)
+	return info, nil
+}
+
+//msgp:ignore serverInfoCache cachedServerInfo
+
+// serverInfoCache holds the ServerInfo results by options, it is only
+// used if ttl is set.
+type serverInfoCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[ServerInfoOpts]cachedServerInfo
+}
+
+// cachedServerInfo is a ServerInfo result along with when it was fetched.
+type cachedServerInfo struct {
+	info    InfoMessage
+	fetched time.Time
+}
 
+// get returns the cached result for opts, if it has not expired.
+func (c *serverInfoCache) get(opts ServerInfoOpts) (InfoMessage, bool) {
+	if c == nil || c.ttl <= 0 {
+		return InfoMessage{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.entries[opts]
+	if !ok || time.Since(cached.fetched) >= c.ttl {
+		return InfoMessage{}, false
+	}
+	return cached.info, true
+}
+
+// set caches info as the result for opts.
+func (c *serverInfoCache) set(opts ServerInfoOpts, info InfoMessage) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[ServerInfoOpts]cachedServerInfo)
+	}
+	c.entries[opts] = cachedServerInfo{info: info, fetched: time.Now()}
+}
+
+func (adm *AdminClient) serverInfo(ctx context.Context, srvOpts ServerInfoOpts) (InfoMessage, error) {
 	values := make(url.Values)
 	values.Set("metrics", strconv.FormatBool(srvOpts.Metrics))
+	if srvOpts.NoDrives {
+		values.Set("drives", "false")
+	}
+	if srvOpts.NoPools {
+		values.Set("pools", "false")
+	}
 
 	resp, err := adm.executeMethod(ctx,
 		http.MethodGet,
@@ -563,5 +973,21 @@ func (adm *AdminClient) ServerInfo(ctx context.Context, options ...func(*ServerI
 		return InfoMessage{}, err
 	}
 
+	// Older servers ignore the options, so trim the response here.
+	if srvOpts.NoDrives {
+		for i := range message.Servers {
+			message.Servers[i].Disks = nil
+		}
+	}
+	if srvOpts.NoMetrics {
+		for i := range message.Servers {
+			for j := range message.Servers[i].Disks {
+				message.Servers[i].Disks[j].Metrics = nil
+			}
+		}
+	}
+	if srvOpts.NoPools {
+		message.Pools = nil
+	}
 	return message, nil
 }
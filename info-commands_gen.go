@@ -8723,6 +8723,24 @@ func (z *ServerInfoOpts) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Metrics")
 				return
 			}
+		case "NoDrives":
+			z.NoDrives, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "NoDrives")
+				return
+			}
+		case "NoPools":
+			z.NoPools, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "NoPools")
+				return
+			}
+		case "NoMetrics":
+			z.NoMetrics, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "NoMetrics")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -8735,10 +8753,10 @@ func (z *ServerInfoOpts) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z ServerInfoOpts) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 1
+func (z *ServerInfoOpts) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
 	// write "Metrics"
-	err = en.Append(0x81, 0xa7, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73)
+	err = en.Append(0x84, 0xa7, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73)
 	if err != nil {
 		return
 	}
@@ -8747,16 +8765,55 @@ func (z ServerInfoOpts) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Metrics")
 		return
 	}
+	// write "NoDrives"
+	err = en.Append(0xa8, 0x4e, 0x6f, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.NoDrives)
+	if err != nil {
+		err = msgp.WrapError(err, "NoDrives")
+		return
+	}
+	// write "NoPools"
+	err = en.Append(0xa7, 0x4e, 0x6f, 0x50, 0x6f, 0x6f, 0x6c, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.NoPools)
+	if err != nil {
+		err = msgp.WrapError(err, "NoPools")
+		return
+	}
+	// write "NoMetrics"
+	err = en.Append(0xa9, 0x4e, 0x6f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.NoMetrics)
+	if err != nil {
+		err = msgp.WrapError(err, "NoMetrics")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z ServerInfoOpts) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *ServerInfoOpts) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// map header, size 4
 	// string "Metrics"
-	o = append(o, 0x81, 0xa7, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73)
+	o = append(o, 0x84, 0xa7, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73)
 	o = msgp.AppendBool(o, z.Metrics)
+	// string "NoDrives"
+	o = append(o, 0xa8, 0x4e, 0x6f, 0x44, 0x72, 0x69, 0x76, 0x65, 0x73)
+	o = msgp.AppendBool(o, z.NoDrives)
+	// string "NoPools"
+	o = append(o, 0xa7, 0x4e, 0x6f, 0x50, 0x6f, 0x6f, 0x6c, 0x73)
+	o = msgp.AppendBool(o, z.NoPools)
+	// string "NoMetrics"
+	o = append(o, 0xa9, 0x4e, 0x6f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73)
+	o = msgp.AppendBool(o, z.NoMetrics)
 	return
 }
 
@@ -8784,6 +8841,24 @@ func (z *ServerInfoOpts) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Metrics")
 				return
 			}
+		case "NoDrives":
+			z.NoDrives, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NoDrives")
+				return
+			}
+		case "NoPools":
+			z.NoPools, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NoPools")
+				return
+			}
+		case "NoMetrics":
+			z.NoMetrics, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NoMetrics")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -8797,8 +8872,8 @@ func (z *ServerInfoOpts) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z ServerInfoOpts) Msgsize() (s int) {
-	s = 1 + 8 + msgp.BoolSize
+func (z *ServerInfoOpts) Msgsize() (s int) {
+	s = 1 + 8 + msgp.BoolSize + 9 + msgp.BoolSize + 8 + msgp.BoolSize + 10 + msgp.BoolSize
 	return
 }
 
@@ -0,0 +1,388 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCacheStatsHitRatio(t *testing.T) {
+	tests := []struct {
+		stats CacheStats
+		want  float64
+	}{
+		{stats: CacheStats{}, want: 0},
+		{stats: CacheStats{Capacity: 100, Used: 50}, want: 0},
+		{stats: CacheStats{Hits: 3, Misses: 1}, want: 0.75},
+		{stats: CacheStats{Hits: 10}, want: 1},
+		{stats: CacheStats{Misses: 10}, want: 0},
+	}
+	for _, test := range tests {
+		if got := test.stats.HitRatio(); got != test.want {
+			t.Errorf("%+v: expected hit ratio %v, got %v", test.stats, test.want, got)
+		}
+	}
+}
+
+func TestDataUsageInfoStream(t *testing.T) {
+	const numBuckets = 10000
+	usage := DataUsageInfo{
+		LastUpdate:   time.Now().UTC(),
+		BucketsCount: numBuckets,
+		BucketsUsage: make(map[string]BucketUsageInfo, numBuckets),
+		TierStats: map[string]TierStats{
+			"WARM-TIER": {TotalSize: 1 << 20, NumObjects: 2},
+		},
+	}
+	for i := 0; i < numBuckets; i++ {
+		usage.BucketsUsage[fmt.Sprintf("bucket-%05d", i)] = BucketUsageInfo{Size: uint64(i), ObjectsCount: 1}
+	}
+	data, err := json.Marshal(usage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	})
+
+	seen := make(map[string]BucketUsageInfo, numBuckets)
+	err = adm.DataUsageInfoStream(context.Background(), func(bucket string, u BucketUsageInfo) error {
+		seen[bucket] = u
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != numBuckets {
+		t.Fatalf("expected %d buckets, got %d", numBuckets, len(seen))
+	}
+	if u := seen["bucket-01234"]; u.Size != 1234 || u.ObjectsCount != 1 {
+		t.Errorf("unexpected usage %+v", u)
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = decodeBucketsUsage(json.NewDecoder(bytes.NewReader(data)), func(string, BucketUsageInfo) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("expected decoding to stop after the first callback, got %v after %d calls", err, calls)
+	}
+
+	err = decodeBucketsUsage(json.NewDecoder(bytes.NewReader([]byte(`{"bucketsUsageInfo":null}`))), func(string, BucketUsageInfo) error {
+		t.Error("unexpected callback")
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDataUsageInfoVersionStats(t *testing.T) {
+	const input = `{"bucketsCount":2,"bucketsUsageInfo":{
+	"versioned":{"size":4096,"objectsCount":3,"versionsCount":12,"deleteMarkersCount":4},
+	"unversioned":{"size":1024,"objectsCount":5,"versionsCount":5}
+}}`
+	var usage DataUsageInfo
+	if err := json.Unmarshal([]byte(input), &usage); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, ok := usage.BucketVersionStats("versioned")
+	if want := (VersionStats{Objects: 3, Versions: 12, DeleteMarkers: 4}); !ok || stats != want {
+		t.Errorf("expected %+v, got %+v (found %v)", want, stats, ok)
+	}
+	if usage.VersionCount("versioned") != 12 || usage.DeleteMarkerCount("versioned") != 4 {
+		t.Errorf("unexpected counts for versioned bucket")
+	}
+	if usage.VersionCount("unversioned") != 5 || usage.DeleteMarkerCount("unversioned") != 0 {
+		t.Errorf("unexpected counts for unversioned bucket")
+	}
+	if _, ok := usage.BucketVersionStats("missing"); ok {
+		t.Error("expected no stats for missing bucket")
+	}
+	if usage.VersionCount("missing") != 0 {
+		t.Error("expected no versions for missing bucket")
+	}
+}
+
+func TestServerInfoWithout(t *testing.T) {
+	var query url.Values
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		// Behave like a server that ignores the options.
+		json.NewEncoder(w).Encode(InfoMessage{
+			Mode: "online",
+			Servers: []ServerProperties{
+				{Endpoint: "node1:9000", State: "online", Disks: []Disk{{Endpoint: "/data1"}}},
+				{Endpoint: "node2:9000", State: "online", Disks: []Disk{{Endpoint: "/data1"}}},
+			},
+			Pools: map[int]map[int]ErasureSetInfo{0: {0: {ID: 0}}},
+		})
+	})
+
+	ctx := context.Background()
+	info, err := adm.ServerInfo(ctx, WithoutDrives(), WithoutPools())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("drives") != "false" || query.Get("pools") != "false" || query.Get("metrics") != "false" {
+		t.Errorf("unexpected query %v", query)
+	}
+	if len(info.Servers) != 2 || info.Servers[1].Endpoint != "node2:9000" {
+		t.Fatalf("unexpected servers %+v", info.Servers)
+	}
+	for _, srv := range info.Servers {
+		if srv.Disks != nil {
+			t.Errorf("%s: expected no drives, got %+v", srv.Endpoint, srv.Disks)
+		}
+	}
+	if info.Pools != nil {
+		t.Errorf("expected no pools, got %+v", info.Pools)
+	}
+
+	info, err = adm.ServerInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Has("drives") || query.Has("pools") {
+		t.Errorf("unexpected query %v", query)
+	}
+	if len(info.Servers[0].Disks) != 1 || len(info.Pools) != 1 {
+		t.Errorf("expected drives and pools, got %+v", info)
+	}
+}
+
+func TestServerInfoWithoutMetrics(t *testing.T) {
+	var query url.Values
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		json.NewEncoder(w).Encode(InfoMessage{
+			Servers: []ServerProperties{
+				{Endpoint: "node1:9000", Disks: []Disk{{Endpoint: "/data1", Metrics: &DiskMetrics{TotalWaiting: 1}}}},
+			},
+		})
+	})
+
+	info, err := adm.ServerInfo(context.Background(), WithDriveMetrics(true), WithoutMetrics())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("metrics") != "false" {
+		t.Errorf("unexpected query %v", query)
+	}
+	if d := info.Servers[0].Disks; len(d) != 1 || d[0].Metrics != nil {
+		t.Errorf("expected drives without metrics, got %+v", d)
+	}
+}
+
+func TestServerInfoCache(t *testing.T) {
+	calls := 0
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		json.NewEncoder(w).Encode(InfoMessage{DeploymentID: fmt.Sprint(calls)})
+	})
+	adm.serverInfoCache.ttl = time.Hour
+
+	for i := 0; i < 2; i++ {
+		info, err := adm.ServerInfo(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.DeploymentID != "1" || calls != 1 {
+			t.Fatalf("call %d: expected cached info from the first request, got %q after %d requests", i, info.DeploymentID, calls)
+		}
+	}
+
+	// Different options are cached separately.
+	if info, err := adm.ServerInfo(context.Background(), WithoutDrives()); err != nil || info.DeploymentID != "2" {
+		t.Fatalf("expected a new request for different options, got %q, %v", info.DeploymentID, err)
+	}
+
+	info, err := adm.ServerInfoRefresh(context.Background())
+	if err != nil || info.DeploymentID != "3" {
+		t.Fatalf("expected refresh to make a new request, got %q, %v", info.DeploymentID, err)
+	}
+	if info, _ = adm.ServerInfo(context.Background()); info.DeploymentID != "3" || calls != 3 {
+		t.Errorf("expected the refreshed info to be cached, got %q after %d requests", info.DeploymentID, calls)
+	}
+
+	adm.serverInfoCache.ttl = 0
+	if info, _ = adm.ServerInfo(context.Background()); info.DeploymentID != "4" {
+		t.Errorf("expected no caching without a TTL, got %q", info.DeploymentID)
+	}
+}
+
+func TestInfoMessageHealthSummary(t *testing.T) {
+	const tib = 1 << 40
+	info := InfoMessage{
+		Backend: ErasureBackend{
+			Type:             "Erasure",
+			StandardSCParity: 2,
+			TotalSets:        []int{1},
+			DrivesPerSet:     []int{4},
+		},
+		Servers: []ServerProperties{
+			{
+				State: string(ItemOnline),
+				Disks: []Disk{
+					{State: DriveStateOk, TotalSpace: tib},
+					{State: DriveStateOk, TotalSpace: tib, Healing: true},
+				},
+			},
+			{
+				State: string(ItemOnline),
+				Disks: []Disk{
+					{State: DriveStateOk, TotalSpace: tib},
+					{State: DriveStateFaulty, TotalSpace: tib},
+				},
+			},
+			{State: string(ItemOffline)},
+			{State: string(ItemInitializing)},
+		},
+	}
+
+	want := ClusterHealthSummary{
+		OnlineServers:  2,
+		OfflineServers: 2,
+		OnlineDrives:   3,
+		OfflineDrives:  1,
+		HealingDrives:  1,
+		RawCapacity:    3 * tib,
+		UsableCapacity: 3 * tib / 2,
+	}
+	if got := info.HealthSummary(); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if got := (InfoMessage{}).HealthSummary(); got != (ClusterHealthSummary{}) {
+		t.Errorf("expected empty summary, got %+v", got)
+	}
+}
+
+func TestTierStatsSub(t *testing.T) {
+	testCases := []struct {
+		name      string
+		cur, prev TierStats
+		want      TierStats
+	}{
+		{
+			name: "growth",
+			cur:  TierStats{TotalSize: 5 << 20, NumVersions: 12, NumObjects: 10},
+			prev: TierStats{TotalSize: 2 << 20, NumVersions: 4, NumObjects: 3},
+			want: TierStats{TotalSize: 3 << 20, NumVersions: 8, NumObjects: 7},
+		},
+		{
+			name: "unchanged",
+			cur:  TierStats{TotalSize: 1 << 20, NumVersions: 1, NumObjects: 1},
+			prev: TierStats{TotalSize: 1 << 20, NumVersions: 1, NumObjects: 1},
+		},
+		{
+			name: "restart",
+			cur:  TierStats{TotalSize: 1 << 20, NumVersions: 2, NumObjects: 2},
+			prev: TierStats{TotalSize: 5 << 20, NumVersions: 12, NumObjects: 10},
+		},
+		{
+			name: "partial restart",
+			cur:  TierStats{TotalSize: 6 << 20, NumVersions: 2, NumObjects: 12},
+			prev: TierStats{TotalSize: 5 << 20, NumVersions: 12, NumObjects: 10},
+			want: TierStats{TotalSize: 1 << 20, NumObjects: 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.cur.Sub(tc.prev); got != tc.want {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestClusterBucketStats(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var v interface{}
+		switch r.URL.Path {
+		case "/minio/admin/v3/accountinfo":
+			v = AccountInfo{
+				Buckets: []BucketAccessInfo{
+					{Name: "photos", Size: 1, Objects: 1, Details: &BucketDetails{Versioning: true, Replication: true}},
+					{Name: "logs", Size: 10, Objects: 2},
+				},
+			}
+		case "/minio/admin/v3/datausageinfo":
+			v = DataUsageInfo{
+				BucketsUsage: map[string]BucketUsageInfo{
+					"photos": {Size: 100, ObjectsCount: 4, VersionsCount: 6, DeleteMarkersCount: 1, ReplicationPendingSize: 20},
+				},
+			}
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		json.NewEncoder(w).Encode(v)
+	})
+
+	stats, err := adm.ClusterBucketStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BucketStat{
+		{Name: "logs", Size: 10, Objects: 2},
+		{Name: "photos", Size: 100, Objects: 4, Versions: 6, DeleteMarkers: 1, Versioning: true, Replication: true, ReplicationPendingSize: 20},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("expected %+v, got %+v", want, stats)
+	}
+}
+
+func TestStartupErrors(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/info" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"servers":[
+			{"state":"online","endpoint":"node1:9000","drives":[
+				{"path":"/data1","state":"ok"},
+				{"path":"/data2","state":"permission-denied"}]},
+			{"state":"offline","endpoint":"node2:9000"}]}`))
+	})
+
+	errs, err := adm.StartupErrors(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []StartupError{
+		{Node: "node1:9000", Drive: "/data2", State: DriveStatePermission},
+		{Node: "node2:9000", State: string(ItemOffline)},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("expected %+v, got %+v", want, errs)
+	}
+}
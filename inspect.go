@@ -20,7 +20,10 @@
 package madmin
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/base64"
 	"errors"
@@ -28,6 +31,12 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/madmin-go/v3/estream"
+	"github.com/secure-io/sio-go"
 )
 
 // InspectOptions provides options to Inspect.
@@ -104,6 +113,180 @@ func (adm *AdminClient) Inspect(ctx context.Context, d InspectOptions) (key []by
 	return key, &closeWrapper{Reader: bior, Closer: resp.Body}, nil
 }
 
+// InspectExtract calls Inspect, decrypts the returned data and extracts
+// the archived files into dst, which is created if missing. The paths
+// of all extracted files are returned.
+//
+// When the server returns a key the data is decrypted with it. Otherwise
+// the data is an encrypted stream; only streams sent in cleartext can be
+// extracted, since the private key matching opts.PublicKey is not known
+// here. Both tar and zip archives are supported.
+func (adm *AdminClient) InspectExtract(ctx context.Context, opts InspectOptions, dst string) ([]string, error) {
+	key, rc, err := adm.Inspect(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	if err = os.MkdirAll(dst, 0o755); err != nil {
+		return nil, err
+	}
+
+	if key != nil {
+		stream, err := sio.AES_256_GCM.Stream(key)
+		if err != nil {
+			return nil, err
+		}
+		// The server encrypts with a zero nonce, since the key is unique.
+		nonce := make([]byte, stream.NonceSize())
+		return extractArchive(stream.DecryptReader(rc, nonce, nil), dst)
+	}
+
+	sr, err := estream.NewReader(rc)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for {
+		st, err := sr.NextStream()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return files, fmt.Errorf("inspect: reading stream: %w", err)
+		}
+		extracted, err := extractArchive(st, dst)
+		files = append(files, extracted...)
+		if err != nil {
+			return files, fmt.Errorf("inspect: extracting %q: %w", st.Name, err)
+		}
+		// Drain any trailing data, so the next stream can be read.
+		if _, err = io.Copy(io.Discard, st); err != nil {
+			return files, err
+		}
+	}
+}
+
+// extractArchive extracts the tar or zip archive read from r into dst
+// and returns the paths of the extracted files.
+func extractArchive(r io.Reader, dst string) ([]string, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(4)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if bytes.Equal(magic, []byte("PK\x03\x04")) {
+		return extractZip(br, dst)
+	}
+	return extractTar(br, dst)
+}
+
+func extractTar(r io.Reader, dst string) ([]string, error) {
+	var files []string
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return files, err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			path, err := extractPath(dst, hdr.Name)
+			if err != nil {
+				return files, err
+			}
+			if err = os.MkdirAll(path, 0o755); err != nil {
+				return files, err
+			}
+		case tar.TypeReg:
+			path, err := extractPath(dst, hdr.Name)
+			if err != nil {
+				return files, err
+			}
+			if err = writeExtractedFile(path, tr); err != nil {
+				return files, err
+			}
+			files = append(files, path)
+		}
+	}
+}
+
+func extractZip(r io.Reader, dst string) ([]string, error) {
+	// zip needs random access, so spool the archive to disk first.
+	tmp, err := os.CreateTemp(dst, ".inspect-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, f := range zr.File {
+		path, err := extractPath(dst, f.Name)
+		if err != nil {
+			return files, err
+		}
+		if f.FileInfo().IsDir() {
+			if err = os.MkdirAll(path, 0o755); err != nil {
+				return files, err
+			}
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		fr, err := f.Open()
+		if err != nil {
+			return files, err
+		}
+		err = writeExtractedFile(path, fr)
+		fr.Close()
+		if err != nil {
+			return files, err
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// extractPath returns the path of the archive entry name inside dst,
+// rejecting entries that would be written outside of it.
+func extractPath(dst, name string) (string, error) {
+	path := filepath.Join(dst, filepath.FromSlash(name))
+	rel, err := filepath.Rel(dst, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(name) {
+		return "", fmt.Errorf("inspect: invalid archive entry %q", name)
+	}
+	return path, nil
+}
+
+func writeExtractedFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 type closeWrapper struct {
 	io.Reader
 	io.Closer
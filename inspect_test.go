@@ -0,0 +1,153 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/minio/madmin-go/v3/estream"
+	"github.com/secure-io/sio-go"
+)
+
+func testInspectTar(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func checkExtracted(t *testing.T, dst string, got []string, want map[string]string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("extracted %d files, want %d: %v", len(got), len(want), got)
+	}
+	sort.Strings(got)
+	for _, path := range got {
+		rel, err := filepath.Rel(dst, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w, ok := want[filepath.ToSlash(rel)]; !ok || string(content) != w {
+			t.Errorf("%s = %q, want %q", rel, content, w)
+		}
+	}
+}
+
+func TestInspectExtract(t *testing.T) {
+	files := map[string]string{
+		"disk1/bucket/object/xl.meta": "meta-1",
+		"disk2/bucket/object/xl.meta": "meta-2",
+	}
+	archive := testInspectTar(t, files)
+
+	key := bytes.Repeat([]byte{0x42}, 32)
+	stream, err := sio.AES_256_GCM.Stream(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body bytes.Buffer
+	body.WriteByte(1)
+	body.Write(key)
+	w := stream.EncryptWriter(&body, make([]byte, stream.NonceSize()), nil)
+	if _, err = w.Write(archive); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body.Bytes())
+	})
+	dst := filepath.Join(t.TempDir(), "out")
+	got, err := adm.InspectExtract(context.Background(), InspectOptions{Volume: "bucket", File: "object/xl.meta"}, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkExtracted(t, dst, got, files)
+}
+
+func TestInspectExtractCleartext(t *testing.T) {
+	files := map[string]string{"disk1/bucket/object/xl.meta": "meta-1"}
+	archive := testInspectTar(t, files)
+
+	var body bytes.Buffer
+	sw := estream.NewWriter(&body)
+	if err := sw.AddKeyPlain(); err != nil {
+		t.Fatal(err)
+	}
+	w, err := sw.AddEncryptedStream("inspect.tar", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Write(archive); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = sw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body.Bytes())
+	})
+	dst := t.TempDir()
+	got, err := adm.InspectExtract(context.Background(), InspectOptions{Volume: "bucket", File: "object/xl.meta"}, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkExtracted(t, dst, got, files)
+}
+
+func TestInspectExtractPathTraversal(t *testing.T) {
+	archive := testInspectTar(t, map[string]string{"../escape": "x"})
+	dst := t.TempDir()
+	if _, err := extractArchive(bytes.NewReader(archive), dst); err == nil {
+		t.Fatal("expected error for entry outside destination")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dst), "escape")); !os.IsNotExist(err) {
+		t.Fatalf("file written outside destination: %v", err)
+	}
+}
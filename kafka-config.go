@@ -0,0 +1,184 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Config keys of the audit_kafka sub-system.
+const (
+	KafkaBrokersKey       = "brokers"
+	KafkaTopicKey         = "topic"
+	KafkaVersionKey       = "version"
+	KafkaSASLKey          = "sasl"
+	KafkaSASLMechanismKey = "sasl_mechanism"
+	KafkaSASLUsernameKey  = "sasl_username"
+	KafkaSASLPasswordKey  = "sasl_password"
+	KafkaTLSKey           = "tls"
+	KafkaTLSSkipVerifyKey = "tls_skip_verify"
+	KafkaTLSClientAuthKey = "tls_client_auth"
+	KafkaClientTLSCertKey = "client_tls_cert"
+	KafkaClientTLSKeyKey  = "client_tls_key"
+)
+
+// SASL mechanisms supported by the Kafka targets of the server.
+const (
+	KafkaSASLMechanismPlain  = "plain"
+	KafkaSASLMechanismSHA256 = "sha256"
+	KafkaSASLMechanismSHA512 = "sha512"
+)
+
+// KafkaSASLConfig holds the SASL authentication of a Kafka target.
+type KafkaSASLConfig struct {
+	Enable bool `json:"enable"`
+	// Mechanism is one of the KafkaSASLMechanism constants,
+	// empty means KafkaSASLMechanismPlain.
+	Mechanism string `json:"mechanism,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"password,omitempty"`
+}
+
+// KafkaTLSConfig holds the TLS settings of a Kafka target.
+type KafkaTLSConfig struct {
+	Enable     bool `json:"enable"`
+	SkipVerify bool `json:"skipVerify,omitempty"`
+	// ClientAuth is the TLS client authentication type, as defined by
+	// crypto/tls.ClientAuthType. Any other than tls.NoClientCert requires
+	// a client certificate.
+	ClientAuth    int    `json:"clientAuth,omitempty"`
+	ClientTLSCert string `json:"clientTLSCert,omitempty"`
+	ClientTLSKey  string `json:"clientTLSKey,omitempty"`
+}
+
+// KafkaConfig holds the configuration of the default Kafka audit log target.
+type KafkaConfig struct {
+	Enable  bool            `json:"enable"`
+	Brokers []string        `json:"brokers"`
+	Topic   string          `json:"topic"`
+	Version string          `json:"version,omitempty"`
+	SASL    KafkaSASLConfig `json:"sasl"`
+	TLS     KafkaTLSConfig  `json:"tls"`
+}
+
+// Validate returns an error if the Kafka configuration is incomplete, such
+// as SASL without credentials or a client certificate without its key.
+// Kerberos (GSSAPI) is not supported by the server, so it is rejected
+// like any other unknown SASL mechanism. Values are sent double quoted,
+// so they must not contain a double quote.
+func (c KafkaConfig) Validate() error {
+	if c.Enable && (len(c.Brokers) == 0 || c.Topic == "") {
+		return errors.New("kafka brokers and topic are required")
+	}
+	for _, v := range append([]string{c.Topic, c.Version, c.SASL.Username, c.SASL.Password, c.TLS.ClientTLSCert, c.TLS.ClientTLSKey}, c.Brokers...) {
+		if strings.Contains(v, KvDoubleQuote) {
+			return errors.New("kafka configuration values must not contain a double quote")
+		}
+	}
+	if c.SASL.Enable {
+		switch c.SASL.Mechanism {
+		case "", KafkaSASLMechanismPlain, KafkaSASLMechanismSHA256, KafkaSASLMechanismSHA512:
+		default:
+			return fmt.Errorf("invalid kafka %s value %q: must be %s, %s or %s", KafkaSASLMechanismKey,
+				c.SASL.Mechanism, KafkaSASLMechanismPlain, KafkaSASLMechanismSHA256, KafkaSASLMechanismSHA512)
+		}
+		if c.SASL.Username == "" || c.SASL.Password == "" {
+			return errors.New("kafka sasl requires a username and password")
+		}
+	}
+	if (c.TLS.ClientTLSCert == "") != (c.TLS.ClientTLSKey == "") {
+		return errors.New("kafka client TLS certificate and key must be set together")
+	}
+	if c.TLS.ClientAuth != 0 && c.TLS.ClientTLSCert == "" {
+		return errors.New("kafka TLS client authentication requires a client certificate and key")
+	}
+	if !c.TLS.Enable && (c.TLS.ClientAuth != 0 || c.TLS.ClientTLSCert != "" || c.TLS.SkipVerify) {
+		return errors.New("kafka TLS settings require TLS to be enabled")
+	}
+	return nil
+}
+
+func (c KafkaConfig) kvs() []string {
+	return []string{
+		EnableKey + KvSeparator + formatBool(c.Enable),
+		KafkaBrokersKey + KvSeparator + KvDoubleQuote + strings.Join(c.Brokers, ",") + KvDoubleQuote,
+		KafkaTopicKey + KvSeparator + KvDoubleQuote + c.Topic + KvDoubleQuote,
+		KafkaVersionKey + KvSeparator + KvDoubleQuote + c.Version + KvDoubleQuote,
+		KafkaSASLKey + KvSeparator + formatBool(c.SASL.Enable),
+		KafkaSASLMechanismKey + KvSeparator + c.SASL.Mechanism,
+		KafkaSASLUsernameKey + KvSeparator + KvDoubleQuote + c.SASL.Username + KvDoubleQuote,
+		KafkaSASLPasswordKey + KvSeparator + KvDoubleQuote + c.SASL.Password + KvDoubleQuote,
+		KafkaTLSKey + KvSeparator + formatBool(c.TLS.Enable),
+		KafkaTLSSkipVerifyKey + KvSeparator + formatBool(c.TLS.SkipVerify),
+		KafkaTLSClientAuthKey + KvSeparator + strconv.Itoa(c.TLS.ClientAuth),
+		KafkaClientTLSCertKey + KvSeparator + KvDoubleQuote + c.TLS.ClientTLSCert + KvDoubleQuote,
+		KafkaClientTLSKeyKey + KvSeparator + KvDoubleQuote + c.TLS.ClientTLSKey + KvDoubleQuote,
+	}
+}
+
+func parseKafkaConfig(cfg SubsysConfig) (c KafkaConfig, err error) {
+	if c.Enable, err = lookupBool(cfg, EnableKey); err != nil {
+		return c, err
+	}
+	c.Brokers = lookupList(cfg, KafkaBrokersKey)
+	c.Topic, _ = cfg.Lookup(KafkaTopicKey)
+	c.Version, _ = cfg.Lookup(KafkaVersionKey)
+	if c.SASL.Enable, err = lookupBool(cfg, KafkaSASLKey); err != nil {
+		return c, err
+	}
+	c.SASL.Mechanism, _ = cfg.Lookup(KafkaSASLMechanismKey)
+	c.SASL.Username, _ = cfg.Lookup(KafkaSASLUsernameKey)
+	c.SASL.Password, _ = cfg.Lookup(KafkaSASLPasswordKey)
+	if c.TLS.Enable, err = lookupBool(cfg, KafkaTLSKey); err != nil {
+		return c, err
+	}
+	if c.TLS.SkipVerify, err = lookupBool(cfg, KafkaTLSSkipVerifyKey); err != nil {
+		return c, err
+	}
+	if c.TLS.ClientAuth, err = lookupInt(cfg, KafkaTLSClientAuthKey); err != nil {
+		return c, err
+	}
+	c.TLS.ClientTLSCert, _ = cfg.Lookup(KafkaClientTLSCertKey)
+	c.TLS.ClientTLSKey, _ = cfg.Lookup(KafkaClientTLSKeyKey)
+	return c, nil
+}
+
+// GetKafkaLogConfig - returns the configuration of the default Kafka audit
+// log target.
+func (adm *AdminClient) GetKafkaLogConfig(ctx context.Context) (KafkaConfig, error) {
+	cfg, err := adm.getSubsysConfig(ctx, AuditKafkaSubSys)
+	if err != nil {
+		return KafkaConfig{}, err
+	}
+	return parseKafkaConfig(cfg)
+}
+
+// SetKafkaLogConfig - validates and sets the configuration of the default
+// Kafka audit log target.
+func (adm *AdminClient) SetKafkaLogConfig(ctx context.Context, c KafkaConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	return adm.setSubsysConfig(ctx, AuditKafkaSubSys, c.kvs()...)
+}
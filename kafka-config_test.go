@@ -0,0 +1,110 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKafkaConfigRoundTrip(t *testing.T) {
+	tests := []KafkaConfig{
+		{Enable: true, Brokers: []string{"kafka1:9092", "kafka2:9092"}, Topic: "audit"},
+		{
+			Enable:  true,
+			Brokers: []string{"kafka1:9093"},
+			Topic:   "audit",
+			Version: "2.8.0",
+			SASL:    KafkaSASLConfig{Enable: true, Mechanism: KafkaSASLMechanismSHA512, Username: "minio", Password: "secret"},
+			TLS:     KafkaTLSConfig{Enable: true, SkipVerify: true, ClientAuth: 4, ClientTLSCert: "/certs/client.crt", ClientTLSKey: "/certs/client.key"},
+		},
+		{
+			Enable:  true,
+			Brokers: []string{"kafka1:9093"},
+			Topic:   "audit logs",
+			SASL:    KafkaSASLConfig{Enable: true, Username: "minio admin", Password: "p@ss word=with spaces"},
+			TLS:     KafkaTLSConfig{Enable: true, ClientTLSCert: "/my certs/client.crt", ClientTLSKey: "/my certs/client.key"},
+		},
+	}
+	for _, want := range tests {
+		if err := want.Validate(); err != nil {
+			t.Fatalf("%+v: unexpected validation error: %v", want, err)
+		}
+		cfgs, err := ParseServerConfigOutput(AuditKafkaSubSys + " " + strings.Join(want.kvs(), " "))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parseKafkaConfig(cfgs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestKafkaConfigValidate(t *testing.T) {
+	base := KafkaConfig{Enable: true, Brokers: []string{"kafka1:9092"}, Topic: "audit"}
+	tests := []struct {
+		name string
+		edit func(c *KafkaConfig)
+	}{
+		{"no brokers", func(c *KafkaConfig) { c.Brokers = nil }},
+		{"no topic", func(c *KafkaConfig) { c.Topic = "" }},
+		{"quoted password", func(c *KafkaConfig) {
+			c.SASL = KafkaSASLConfig{Enable: true, Username: "minio", Password: `se"cret`}
+		}},
+		{"sasl without credentials", func(c *KafkaConfig) {
+			c.SASL = KafkaSASLConfig{Enable: true, Mechanism: KafkaSASLMechanismPlain}
+		}},
+		{"sasl without password", func(c *KafkaConfig) {
+			c.SASL = KafkaSASLConfig{Enable: true, Mechanism: KafkaSASLMechanismSHA256, Username: "minio"}
+		}},
+		{"sasl kerberos", func(c *KafkaConfig) {
+			c.SASL = KafkaSASLConfig{Enable: true, Mechanism: "gssapi", Username: "minio", Password: "secret"}
+		}},
+		{"client auth without certificate", func(c *KafkaConfig) {
+			c.TLS = KafkaTLSConfig{Enable: true, ClientAuth: 4}
+		}},
+		{"certificate without key", func(c *KafkaConfig) {
+			c.TLS = KafkaTLSConfig{Enable: true, ClientTLSCert: "/certs/client.crt"}
+		}},
+		{"key without certificate", func(c *KafkaConfig) {
+			c.TLS = KafkaTLSConfig{Enable: true, ClientTLSKey: "/certs/client.key"}
+		}},
+		{"client certificate without tls", func(c *KafkaConfig) {
+			c.TLS = KafkaTLSConfig{ClientTLSCert: "/certs/client.crt", ClientTLSKey: "/certs/client.key"}
+		}},
+	}
+	for _, test := range tests {
+		c := base
+		test.edit(&c)
+		if err := c.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", test.name)
+		}
+	}
+
+	// A disabled target may be incomplete.
+	if err := (KafkaConfig{}).Validate(); err != nil {
+		t.Errorf("unexpected validation error for a disabled target: %v", err)
+	}
+}
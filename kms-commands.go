@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 )
 
@@ -129,6 +130,27 @@ type KMSMetrics struct {
 	StackAlloc  int64 `json:"kes_system_mem_stack_used"`
 }
 
+// Sub returns the requests and log events since prev, such as within a
+// polling interval, so rates can be computed from them. The latency
+// histogram is subtracted per bucket. A counter below its previous value,
+// after a KMS restart, gives a change of zero. Gauges, like the active
+// requests and the system stats, are those of m.
+func (m KMSMetrics) Sub(prev KMSMetrics) KMSMetrics {
+	d := m
+	d.RequestOK = max(m.RequestOK-prev.RequestOK, 0)
+	d.RequestErr = max(m.RequestErr-prev.RequestErr, 0)
+	d.RequestFail = max(m.RequestFail-prev.RequestFail, 0)
+	d.AuditEvents = max(m.AuditEvents-prev.AuditEvents, 0)
+	d.ErrorEvents = max(m.ErrorEvents-prev.ErrorEvents, 0)
+	if m.LatencyHistogram != nil {
+		d.LatencyHistogram = make(map[int64]int64, len(m.LatencyHistogram))
+		for bucket, n := range m.LatencyHistogram {
+			d.LatencyHistogram[bucket] = max(n-prev.LatencyHistogram[bucket], 0)
+		}
+	}
+	return d
+}
+
 type KMSAPI struct {
 	Method  string
 	Path    string
@@ -299,6 +321,37 @@ func (adm *AdminClient) GetKeyStatus(ctx context.Context, keyID string) (*KMSKey
 	return &keyInfo, nil
 }
 
+// kmsKeysStatusWorkers is the number of keys KMSKeysStatus
+// checks concurrently.
+const kmsKeysStatusWorkers = 8
+
+// KMSKeysStatus requests the status of several keys, up to eight of them
+// concurrently. The status of every key that could be checked is returned
+// in the first map, the error of every other key in the second map. Both
+// are keyed by key ID.
+func (adm *AdminClient) KMSKeysStatus(ctx context.Context, keyIDs ...string) (map[string]KMSKeyStatus, map[string]error) {
+	var mu sync.Mutex
+	statuses := make(map[string]KMSKeyStatus, len(keyIDs))
+	errs := make(map[string]error)
+	runConcurrently(len(keyIDs), kmsKeysStatusWorkers, func(i int) {
+		var (
+			st  *KMSKeyStatus
+			err = ctx.Err()
+		)
+		if err == nil {
+			st, err = adm.GetKeyStatus(ctx, keyIDs[i])
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs[keyIDs[i]] = err
+			return
+		}
+		statuses[keyIDs[i]] = *st
+	})
+	return statuses, errs
+}
+
 // KMSKeyStatus contains some status information about a KMS master key.
 // The MinIO server tries to access the KMS and perform encryption and
 // decryption operations. If the MinIO server can access the KMS and
@@ -0,0 +1,106 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestKMSKeysStatus(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/kms/v1/key/status" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		keyID := r.URL.Query().Get("key-id")
+		if keyID == "unknown" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Code":"XMinioKMSKeyNotFound","Message":"key not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(KMSKeyStatus{KeyID: keyID})
+	})
+
+	statuses, errs := adm.KMSKeysStatus(context.Background(), "key-1", "unknown", "key-2")
+	want := map[string]KMSKeyStatus{
+		"key-1": {KeyID: "key-1"},
+		"key-2": {KeyID: "key-2"},
+	}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("expected %v, got %v", want, statuses)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", errs)
+	}
+	if code := ToErrorResponse(errs["unknown"]).Code; code != "XMinioKMSKeyNotFound" {
+		t.Errorf("unexpected error code %q", code)
+	}
+}
+
+func TestKMSMetricsSub(t *testing.T) {
+	prev := KMSMetrics{
+		RequestOK:        100,
+		RequestErr:       5,
+		RequestFail:      1,
+		RequestActive:    3,
+		AuditEvents:      50,
+		LatencyHistogram: map[int64]int64{10: 80, 100: 20},
+		UpTime:           60,
+	}
+	cur := KMSMetrics{
+		RequestOK:        150,
+		RequestErr:       7,
+		RequestFail:      1,
+		RequestActive:    2,
+		AuditEvents:      60,
+		LatencyHistogram: map[int64]int64{10: 120, 100: 30},
+		UpTime:           120,
+	}
+	want := KMSMetrics{
+		RequestOK:        50,
+		RequestErr:       2,
+		RequestActive:    2,
+		AuditEvents:      10,
+		LatencyHistogram: map[int64]int64{10: 40, 100: 10},
+		UpTime:           120,
+	}
+	if got := cur.Sub(prev); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	// After a restart the counters start over and must not go negative.
+	restarted := KMSMetrics{
+		RequestOK:        10,
+		RequestErr:       1,
+		LatencyHistogram: map[int64]int64{10: 9, 100: 1},
+		UpTime:           5,
+	}
+	want = KMSMetrics{
+		LatencyHistogram: map[int64]int64{10: 0, 100: 0},
+		UpTime:           5,
+	}
+	if got := restarted.Sub(prev); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
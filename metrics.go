@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"runtime/metrics"
@@ -68,6 +69,7 @@ const (
 // MetricsOptions are options provided to Metrics call.
 type MetricsOptions struct {
 	Type     MetricType    // Return only these metric types. Several types can be combined using |. Leave at 0 to return all.
+	Exclude  MetricType    // Remove these metric types from Type. Takes precedence over Type.
 	N        int           // Maximum number of samples to return. 0 will return endless stream.
 	Interval time.Duration // Interval between samples. Will be rounded up to 1s.
 	Hosts    []string      // Leave empty for all
@@ -78,12 +80,20 @@ type MetricsOptions struct {
 	ByDepID  string
 }
 
-// Metrics makes an admin call to retrieve metrics.
-// The provided function is called for each received entry.
-func (adm *AdminClient) Metrics(ctx context.Context, o MetricsOptions, out func(RealtimeMetrics)) (err error) {
-	path := fmt.Sprintf(adminAPIPrefix + "/metrics")
+// types returns the metric types requested by o,
+// with Type resolved to MetricsAll if unset and Exclude removed.
+func (o MetricsOptions) types() MetricType {
+	t := o.Type
+	if t == 0 {
+		t = MetricsAll
+	}
+	return t &^ o.Exclude
+}
+
+// queryValues returns the query parameters for a metrics request.
+func (o MetricsOptions) queryValues() url.Values {
 	q := make(url.Values)
-	q.Set("types", strconv.FormatUint(uint64(o.Type), 10))
+	q.Set("types", strconv.FormatUint(uint64(o.types()), 10))
 	q.Set("n", strconv.Itoa(o.N))
 	q.Set("interval", o.Interval.String())
 	q.Set("hosts", strings.Join(o.Hosts, ","))
@@ -100,11 +110,20 @@ func (adm *AdminClient) Metrics(ctx context.Context, o MetricsOptions, out func(
 	if o.ByDepID != "" {
 		q.Set("by-depID", o.ByDepID)
 	}
+	return q
+}
 
+// Metrics makes an admin call to retrieve metrics.
+// The provided function is called for each received entry.
+func (adm *AdminClient) Metrics(ctx context.Context, o MetricsOptions, out func(RealtimeMetrics)) (err error) {
+	if out == nil {
+		return errors.New("metrics: no output function provided")
+	}
+	path := fmt.Sprintf(adminAPIPrefix + "/metrics")
 	resp, err := adm.executeMethod(ctx,
 		http.MethodGet, requestData{
 			relPath:     path,
-			queryValues: q,
+			queryValues: o.queryValues(),
 		},
 	)
 	if err != nil {
@@ -121,6 +140,9 @@ func (adm *AdminClient) Metrics(ctx context.Context, o MetricsOptions, out func(
 		var m RealtimeMetrics
 		err := dec.Decode(&m)
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
 			if errors.Is(err, io.EOF) {
 				err = io.ErrUnexpectedEOF
 			}
@@ -143,6 +165,9 @@ func (m MetricType) Contains(x MetricType) bool {
 // This is intended to be expanded over time to cover more types.
 type RealtimeMetrics struct {
 	// Error indicates an error occurred.
+	// Errors of hosts that could not be reached are
+	// reported as "host: error", the metrics of all
+	// other hosts are still returned.
 	Errors []string `json:"errors,omitempty"`
 	// Hosts indicates the scanned hosts
 	Hosts      []string              `json:"hosts"`
@@ -153,6 +178,78 @@ type RealtimeMetrics struct {
 	Final bool `json:"final"`
 }
 
+// FailedHosts returns the hosts that reported an error, sorted.
+func (r RealtimeMetrics) FailedHosts() []string {
+	var hosts []string
+	seen := make(map[string]struct{}, len(r.Errors))
+	for _, e := range r.Errors {
+		host, _, ok := strings.Cut(e, ": ")
+		if !ok || host == "" {
+			continue
+		}
+		if _, ok := seen[host]; ok {
+			continue
+		}
+		seen[host] = struct{}{}
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// Summarize returns a one line summary of r for status lines, such as
+// "12 nodes (1 unreachable), 48 drives (2 healing), 1.2k drive ops/s".
+// The drive parts are only included if drive metrics were collected, the
+// operations per second are those of the last minute. Realtime metrics do
+// not include S3 request rates or replication lag, so neither is reported.
+func (r RealtimeMetrics) Summarize() string {
+	parts := []string{pluralize(len(r.Hosts), "node")}
+	if failed := len(r.FailedHosts()); failed > 0 {
+		parts[0] += fmt.Sprintf(" (%d unreachable)", failed)
+	}
+	if d := r.Aggregated.Disk; d != nil {
+		drives := pluralize(d.NDisks, "drive")
+		var states []string
+		if d.Healing > 0 {
+			states = append(states, fmt.Sprintf("%d healing", d.Healing))
+		}
+		if d.Offline > 0 {
+			states = append(states, fmt.Sprintf("%d offline", d.Offline))
+		}
+		if len(states) > 0 {
+			drives += " (" + strings.Join(states, ", ") + ")"
+		}
+		parts = append(parts, drives)
+
+		var ops uint64
+		for _, a := range d.LastMinute.Operations {
+			ops += a.Count
+		}
+		parts = append(parts, formatRate(float64(ops)/time.Minute.Seconds())+" drive ops/s")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pluralize returns n followed by noun, in plural unless n is one.
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+// formatRate formats v with one decimal and a k or M suffix if needed.
+func formatRate(v float64) string {
+	switch {
+	case v >= 1e6:
+		return strconv.FormatFloat(v/1e6, 'f', 1, 64) + "M"
+	case v >= 1e3:
+		return strconv.FormatFloat(v/1e3, 'f', 1, 64) + "k"
+	default:
+		return strconv.FormatFloat(v, 'f', 1, 64)
+	}
+}
+
 // Metrics contains all metric types.
 type Metrics struct {
 	Scanner    *ScannerMetrics    `json:"scanner,omitempty"`
@@ -295,10 +392,7 @@ func (s *ScannerMetrics) Merge(other *ScannerMetrics) {
 		if len(otherSt) == 0 {
 			continue
 		}
-		_, ok := s.PerBucketStats[bucket]
-		if !ok {
-			s.PerBucketStats[bucket] = otherSt
-		}
+		s.PerBucketStats[bucket] = mergeBucketScanInfo(s.PerBucketStats[bucket], otherSt)
 	}
 
 	if s.CurrentCycle < other.CurrentCycle {
@@ -347,7 +441,39 @@ func (s *ScannerMetrics) Merge(other *ScannerMetrics) {
 	sort.Strings(s.ActivePaths)
 }
 
-// DiskIOStats contains IO stats of a single drive
+// mergeBucketScanInfo returns the scan info of a and b combined,
+// sorted by pool and set. When both contain the same erasure set,
+// the most recently updated entry is kept.
+func mergeBucketScanInfo(a, b []BucketScanInfo) []BucketScanInfo {
+	type poolSet struct{ pool, set int }
+	merged := make([]BucketScanInfo, 0, len(a)+len(b))
+	idx := make(map[poolSet]int, len(a)+len(b))
+	for _, infos := range [][]BucketScanInfo{a, b} {
+		for _, info := range infos {
+			key := poolSet{pool: info.Pool, set: info.Set}
+			i, ok := idx[key]
+			if !ok {
+				idx[key] = len(merged)
+				merged = append(merged, info)
+				continue
+			}
+			if merged[i].LastUpdate.Before(info.LastUpdate) {
+				merged[i] = info
+			}
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Pool != merged[j].Pool {
+			return merged[i].Pool < merged[j].Pool
+		}
+		return merged[i].Set < merged[j].Set
+	})
+	return merged
+}
+
+// DiskIOStats contains IO stats of a single drive.
+// The misspelled JSON tags of WriteSectors and DiscardSectors match
+// what the server sends and must be kept as is.
 type DiskIOStats struct {
 	ReadIOs        uint64 `json:"read_ios"`
 	ReadMerges     uint64 `json:"read_merges"`
@@ -368,6 +494,60 @@ type DiskIOStats struct {
 	FlushTicks     uint64 `json:"flush_ticks"`
 }
 
+// Sub returns the difference of the counters in s since the earlier
+// sample prev. Counters that went backwards, e.g. because a drive was
+// replaced or a server restarted, are returned as 0. CurrentIOs is not
+// a counter and is kept as is.
+func (s DiskIOStats) Sub(prev DiskIOStats) DiskIOStats {
+	return DiskIOStats{
+		ReadIOs:        counterDelta(s.ReadIOs, prev.ReadIOs),
+		ReadMerges:     counterDelta(s.ReadMerges, prev.ReadMerges),
+		ReadSectors:    counterDelta(s.ReadSectors, prev.ReadSectors),
+		ReadTicks:      counterDelta(s.ReadTicks, prev.ReadTicks),
+		WriteIOs:       counterDelta(s.WriteIOs, prev.WriteIOs),
+		WriteMerges:    counterDelta(s.WriteMerges, prev.WriteMerges),
+		WriteSectors:   counterDelta(s.WriteSectors, prev.WriteSectors),
+		WriteTicks:     counterDelta(s.WriteTicks, prev.WriteTicks),
+		CurrentIOs:     s.CurrentIOs,
+		TotalTicks:     counterDelta(s.TotalTicks, prev.TotalTicks),
+		ReqTicks:       counterDelta(s.ReqTicks, prev.ReqTicks),
+		DiscardIOs:     counterDelta(s.DiscardIOs, prev.DiscardIOs),
+		DiscardMerges:  counterDelta(s.DiscardMerges, prev.DiscardMerges),
+		DiscardSectors: counterDelta(s.DiscardSectors, prev.DiscardSectors),
+		DiscardTicks:   counterDelta(s.DiscardTicks, prev.DiscardTicks),
+		FlushIOs:       counterDelta(s.FlushIOs, prev.FlushIOs),
+		FlushTicks:     counterDelta(s.FlushTicks, prev.FlushTicks),
+	}
+}
+
+func counterDelta(cur, prev uint64) uint64 {
+	if cur < prev {
+		return 0
+	}
+	return cur - prev
+}
+
+// add other to s.
+func (s *DiskIOStats) add(other DiskIOStats) {
+	s.ReadIOs += other.ReadIOs
+	s.ReadMerges += other.ReadMerges
+	s.ReadSectors += other.ReadSectors
+	s.ReadTicks += other.ReadTicks
+	s.WriteIOs += other.WriteIOs
+	s.WriteMerges += other.WriteMerges
+	s.WriteSectors += other.WriteSectors
+	s.WriteTicks += other.WriteTicks
+	s.CurrentIOs += other.CurrentIOs
+	s.TotalTicks += other.TotalTicks
+	s.ReqTicks += other.ReqTicks
+	s.DiscardIOs += other.DiscardIOs
+	s.DiscardMerges += other.DiscardMerges
+	s.DiscardSectors += other.DiscardSectors
+	s.DiscardTicks += other.DiscardTicks
+	s.FlushIOs += other.FlushIOs
+	s.FlushTicks += other.FlushTicks
+}
+
 // DiskMetric contains metrics for one or more disks.
 type DiskMetric struct {
 	// Time these metrics were collected
@@ -422,6 +602,170 @@ func (d *DiskMetric) Merge(other *DiskMetric) {
 		total.Merge(v)
 		d.LastMinute.Operations[k] = total
 	}
+	d.IOStats.add(other.IOStats)
+}
+
+// Utilization returns the average utilization in percent of the drives in d
+// between the earlier sample prev and d. IOStats holds the cumulative
+// counters of the drives, so the busy time is taken from the difference
+// of both samples over the time between their CollectedAt.
+// It returns 0 if there are no drives or d was not collected after prev.
+func (d DiskMetric) Utilization(prev DiskMetric) float64 {
+	window := d.CollectedAt.Sub(prev.CollectedAt)
+	if d.NDisks <= 0 || window <= 0 {
+		return 0
+	}
+	// TotalTicks is the time in milliseconds the drives were busy.
+	busy := float64(d.IOStats.Sub(prev.IOStats).TotalTicks) / float64(window.Milliseconds())
+	return busy * 100 / float64(d.NDisks)
+}
+
+// diskSectorSize is the size in bytes of the sectors counted in DiskIOStats.
+const diskSectorSize = 512
+
+// Throughput returns the combined read and write throughput in bytes per
+// second of the drives in d between the earlier sample prev and d, taken
+// from the difference of the cumulative sector counters over the time
+// between their CollectedAt. It returns 0 if d was not collected after prev.
+func (d DiskMetric) Throughput(prev DiskMetric) (readBps, writeBps float64) {
+	window := d.CollectedAt.Sub(prev.CollectedAt)
+	if window <= 0 {
+		return 0, 0
+	}
+	delta := d.IOStats.Sub(prev.IOStats)
+	secs := window.Seconds()
+	return float64(delta.ReadSectors*diskSectorSize) / secs, float64(delta.WriteSectors*diskSectorSize) / secs
+}
+
+// DiskThroughput returns the combined read and write throughput in bytes per
+// second of all drives in m since the earlier sample prev, e.g. the metrics
+// returned by the previous call to Metrics. Both samples must cover the
+// same hosts. It returns 0 if either sample has no disk metrics.
+func (m Metrics) DiskThroughput(prev Metrics) (readBps, writeBps float64) {
+	if m.Disk == nil || prev.Disk == nil {
+		return 0, 0
+	}
+	return m.Disk.Throughput(*prev.Disk)
+}
+
+//msgp:ignore DriveLatency
+
+// DriveLatency contains the read and write latency of a drive over the last
+// minute. The server only tracks the count, accumulated, minimum and maximum
+// time of every operation, so percentiles cannot be derived; use Avg and
+// MaxTime of Read and Write instead.
+type DriveLatency struct {
+	Drive string      `json:"drive"`
+	Read  TimedAction `json:"read"`
+	Write TimedAction `json:"write"`
+}
+
+// DriveLatencies returns the last minute read and write latency of every
+// drive in the cluster, sorted by drive.
+func (adm *AdminClient) DriveLatencies(ctx context.Context) ([]DriveLatency, error) {
+	var byDisk map[string]DiskMetric
+	err := adm.Metrics(ctx, MetricsOptions{Type: MetricsDisk, N: 1, ByDisk: true}, func(m RealtimeMetrics) {
+		if len(m.ByDisk) > 0 {
+			byDisk = m.ByDisk
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return driveLatencies(byDisk), nil
+}
+
+// driveLatencies returns the latencies of the drives in byDisk, combining
+// the last minute storage operations into reads and writes.
+func driveLatencies(byDisk map[string]DiskMetric) []DriveLatency {
+	latencies := make([]DriveLatency, 0, len(byDisk))
+	for drive, m := range byDisk {
+		l := DriveLatency{Drive: drive}
+		for op, a := range m.LastMinute.Operations {
+			var t *TimedAction
+			switch {
+			case strings.HasPrefix(op, "Read"):
+				t = &l.Read
+			case strings.HasPrefix(op, "Write"), op == "CreateFile", op == "AppendFile",
+				op == "RenameData", op == "RenameFile", op == "UpdateMetadata":
+				t = &l.Write
+			default:
+				continue
+			}
+			if t.Count == 0 {
+				*t = a
+			} else {
+				t.Merge(a)
+			}
+		}
+		latencies = append(latencies, l)
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i].Drive < latencies[j].Drive
+	})
+	return latencies
+}
+
+//msgp:ignore DriveOutlier
+
+// DriveOutlier is a drive with a latency well above the mean of all drives.
+type DriveOutlier struct {
+	Drive   string        `json:"drive"`
+	Latency time.Duration `json:"latency"` // Average latency of reads and writes
+	Mean    time.Duration `json:"mean"`    // Mean latency of all drives
+	StdDevs float64       `json:"stdDevs"` // Standard deviations above the mean
+}
+
+// DrivePerformanceOutliers returns the drives with a last minute latency more
+// than stddevThreshold standard deviations above the mean of all drives,
+// slowest first. Drives without any reads or writes are left out.
+func (adm *AdminClient) DrivePerformanceOutliers(ctx context.Context, stddevThreshold float64) ([]DriveOutlier, error) {
+	latencies, err := adm.DriveLatencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return driveOutliers(latencies, stddevThreshold), nil
+}
+
+// driveOutliers returns the drives in latencies with a latency more than
+// threshold standard deviations above the mean.
+func driveOutliers(latencies []DriveLatency, threshold float64) []DriveOutlier {
+	drives := make([]DriveOutlier, 0, len(latencies))
+	var sum float64
+	for _, l := range latencies {
+		rw := l.Read
+		rw.Merge(l.Write)
+		if rw.Count == 0 {
+			continue
+		}
+		drives = append(drives, DriveOutlier{Drive: l.Drive, Latency: rw.Avg()})
+		sum += float64(rw.Avg())
+	}
+	if len(drives) < 2 {
+		return nil
+	}
+	mean := sum / float64(len(drives))
+	var variance float64
+	for _, d := range drives {
+		variance += math.Pow(float64(d.Latency)-mean, 2)
+	}
+	stddev := math.Sqrt(variance / float64(len(drives)))
+	if stddev == 0 {
+		return nil
+	}
+
+	var outliers []DriveOutlier
+	for _, d := range drives {
+		d.Mean = time.Duration(mean)
+		d.StdDevs = (float64(d.Latency) - mean) / stddev
+		if d.StdDevs > threshold {
+			outliers = append(outliers, d)
+		}
+	}
+	sort.Slice(outliers, func(i, j int) bool {
+		return outliers[i].StdDevs > outliers[j].StdDevs
+	})
+	return outliers
 }
 
 // OSMetrics contains metrics for OS operations.
@@ -523,6 +867,21 @@ type KeyRotationInfo struct {
 	ObjectsFailed int64 `json:"objectsFailed"`
 }
 
+// Progress returns the number of objects processed and failed by the job,
+// taken from whichever job type specific info is set.
+// ok is false if the job carries no type specific info.
+func (j JobMetric) Progress() (done, failed int64, ok bool) {
+	switch {
+	case j.Replicate != nil:
+		return j.Replicate.Objects, j.Replicate.ObjectsFailed, true
+	case j.KeyRotate != nil:
+		return j.KeyRotate.Objects, j.KeyRotate.ObjectsFailed, true
+	case j.Expired != nil:
+		return j.Expired.Objects, j.Expired.ObjectsFailed, true
+	}
+	return 0, 0, false
+}
+
 // Merge other into 'o'.
 func (o *BatchJobMetrics) Merge(other *BatchJobMetrics) {
 	if other == nil || len(other.Jobs) == 0 {
@@ -541,6 +900,20 @@ func (o *BatchJobMetrics) Merge(other *BatchJobMetrics) {
 	}
 }
 
+// FilterByType returns a copy of o containing only the jobs of the given type.
+func (o BatchJobMetrics) FilterByType(jobType string) BatchJobMetrics {
+	res := BatchJobMetrics{
+		CollectedAt: o.CollectedAt,
+		Jobs:        make(map[string]JobMetric),
+	}
+	for id, job := range o.Jobs {
+		if job.JobType == jobType {
+			res.Jobs[id] = job
+		}
+	}
+	return res
+}
+
 // SiteResyncMetrics contains metrics for site resync operation
 type SiteResyncMetrics struct {
 	// Time these metrics were collected
@@ -670,6 +1043,79 @@ func (m *MemMetrics) Merge(other *MemMetrics) {
 	m.Info.Limit += other.Info.Limit
 }
 
+// MemPressureCriticalPercent is the percentage of its memory limit in use
+// above which a node is considered at risk of running out of memory.
+const MemPressureCriticalPercent = 90
+
+//msgp:ignore NodeMemPressure
+
+// NodeMemPressure is the memory pressure of a node.
+type NodeMemPressure struct {
+	Host string `json:"host"`
+	// UsedPercent is the percentage of the total memory in use.
+	UsedPercent float64 `json:"usedPercent"`
+	// SwapUsedPercent is the percentage of the swap space in use,
+	// 0 if the node has no swap.
+	SwapUsedPercent float64 `json:"swapUsedPercent"`
+	// Limit is the cgroup memory limit, or the total memory if no
+	// lower limit is set.
+	Limit uint64 `json:"limit"`
+	// LimitUsedPercent is the percentage of Limit in use.
+	LimitUsedPercent float64 `json:"limitUsedPercent"`
+}
+
+// Critical returns whether the memory in use is close to the limit of the node.
+func (p NodeMemPressure) Critical() bool {
+	return p.LimitUsedPercent >= MemPressureCriticalPercent
+}
+
+// MemoryPressure returns the memory pressure of every node, sorted by host.
+// Nodes that could not report their memory are left out.
+func (adm *AdminClient) MemoryPressure(ctx context.Context) ([]NodeMemPressure, error) {
+	var byHost map[string]Metrics
+	err := adm.Metrics(ctx, MetricsOptions{Type: MetricsMem, N: 1, ByHost: true}, func(m RealtimeMetrics) {
+		if len(m.ByHost) > 0 {
+			byHost = m.ByHost
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return memPressure(byHost), nil
+}
+
+// memPressure returns the memory pressure of the hosts in byHost.
+func memPressure(byHost map[string]Metrics) []NodeMemPressure {
+	nodes := make([]NodeMemPressure, 0, len(byHost))
+	for host, m := range byHost {
+		if m.Mem == nil || m.Mem.Info.Total == 0 {
+			continue
+		}
+		info := m.Mem.Info
+		used := info.Used
+		if info.Available > 0 && info.Available <= info.Total {
+			used = info.Total - info.Available
+		}
+		p := NodeMemPressure{
+			Host:        host,
+			UsedPercent: float64(used) / float64(info.Total) * 100,
+			Limit:       info.Limit,
+		}
+		if p.Limit == 0 || p.Limit > info.Total {
+			p.Limit = info.Total
+		}
+		p.LimitUsedPercent = math.Min(float64(used)/float64(p.Limit)*100, 100)
+		if info.SwapSpaceTotal > 0 && info.SwapSpaceFree <= info.SwapSpaceTotal {
+			p.SwapUsedPercent = float64(info.SwapSpaceTotal-info.SwapSpaceFree) / float64(info.SwapSpaceTotal) * 100
+		}
+		nodes = append(nodes, p)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Host < nodes[j].Host
+	})
+	return nodes
+}
+
 //msgp:replace cpu.TimesStat with:cpuTimesStat
 //msgp:replace load.AvgStat with:loadAvgStat
 
@@ -833,3 +1279,116 @@ func (m *RuntimeMetrics) Merge(other *RuntimeMetrics) {
 	}
 	m.N += other.N
 }
+
+// Names of the Go runtime metrics used by RuntimeMetrics.Summary,
+// as defined by the runtime/metrics package.
+const (
+	runtimeGoroutinesMetric  = "/sched/goroutines:goroutines"
+	runtimeGoMaxProcsMetric  = "/sched/gomaxprocs:threads"
+	runtimeHeapObjectsMetric = "/memory/classes/heap/objects:bytes"
+	runtimeLiveObjectsMetric = "/gc/heap/objects:objects"
+	runtimeGCCyclesMetric    = "/gc/cycles/total:gc-cycles"
+	runtimeGCPausesMetric    = "/gc/pauses:seconds"
+	runtimeGCCPUMetric       = "/cpu/classes/gc/total:cpu-seconds"
+)
+
+//msgp:ignore RuntimeSummary
+
+// RuntimeSummary holds the commonly used Go runtime metrics. When taken from
+// aggregated metrics the values are summed over all nodes.
+type RuntimeSummary struct {
+	Goroutines  uint64 `json:"goroutines"`
+	GoMaxProcs  uint64 `json:"goMaxProcs"`
+	HeapInUse   uint64 `json:"heapInUse"`   // Bytes occupied by live and unswept heap objects
+	LiveObjects uint64 `json:"liveObjects"` // Number of objects on the heap
+	GCCycles    uint64 `json:"gcCycles"`    // Number of completed GC cycles
+	GCPauses    uint64 `json:"gcPauses"`    // Number of stop-the-world GC pauses
+	// GCCPUSeconds is the CPU time spent on garbage collection.
+	GCCPUSeconds float64 `json:"gcCPUSeconds"`
+	// GCPauseAvg is the average GC pause, estimated from the pause histogram.
+	GCPauseAvg time.Duration `json:"gcPauseAvg"`
+}
+
+// Summary returns the commonly used metrics of m. Metrics that were not
+// collected are zero.
+func (m RuntimeMetrics) Summary() RuntimeSummary {
+	s := RuntimeSummary{
+		Goroutines:  m.UintMetrics[runtimeGoroutinesMetric],
+		GoMaxProcs:  m.UintMetrics[runtimeGoMaxProcsMetric],
+		HeapInUse:   m.UintMetrics[runtimeHeapObjectsMetric],
+		LiveObjects: m.UintMetrics[runtimeLiveObjectsMetric],
+		GCCycles:    m.UintMetrics[runtimeGCCyclesMetric],
+
+		GCCPUSeconds: m.FloatMetrics[runtimeGCCPUMetric],
+	}
+	var sum float64
+	hist := m.HistMetrics[runtimeGCPausesMetric]
+	for i, n := range hist.Counts {
+		if n == 0 || i+1 >= len(hist.Buckets) {
+			continue
+		}
+		s.GCPauses += n
+		sum += float64(n) * histBucketValue(hist.Buckets[i], hist.Buckets[i+1])
+	}
+	if s.GCPauses > 0 {
+		s.GCPauseAvg = time.Duration(sum / float64(s.GCPauses) * float64(time.Second))
+	}
+	return s
+}
+
+// GCPausePercentile returns the p-th percentile, 0 to 100, of the GC pauses
+// in the pause histogram, interpolating linearly within the histogram bucket
+// it falls into. False is returned if there is no pause histogram, it holds
+// no pauses or p is out of range.
+func (m RuntimeMetrics) GCPausePercentile(p float64) (time.Duration, bool) {
+	hist, ok := m.HistMetrics[runtimeGCPausesMetric]
+	if !ok || p < 0 || p > 100 {
+		return 0, false
+	}
+	var total uint64
+	for _, n := range hist.Counts {
+		total += n
+	}
+	if total == 0 || len(hist.Buckets) != len(hist.Counts)+1 {
+		return 0, false
+	}
+
+	rank := p / 100 * float64(total)
+	var seen float64
+	for i, n := range hist.Counts {
+		if n == 0 || seen+float64(n) < rank {
+			seen += float64(n)
+			continue
+		}
+		lower, upper := hist.Buckets[i], hist.Buckets[i+1]
+		var v float64
+		switch {
+		case math.IsInf(lower, -1):
+			v = upper
+		case math.IsInf(upper, 1):
+			v = lower
+		default:
+			v = lower + (upper-lower)*(rank-seen)/float64(n)
+		}
+		return time.Duration(v * float64(time.Second)), true
+	}
+	return 0, false
+}
+
+// GCPauseP99 returns the 99th percentile of the GC pauses,
+// see GCPausePercentile.
+func (m RuntimeMetrics) GCPauseP99() (time.Duration, bool) {
+	return m.GCPausePercentile(99)
+}
+
+// histBucketValue returns the value representing the histogram bucket with
+// the given bounds: its midpoint, or its finite bound if it is unbounded.
+func histBucketValue(lower, upper float64) float64 {
+	switch {
+	case math.IsInf(lower, -1):
+		return upper
+	case math.IsInf(upper, 1):
+		return lower
+	}
+	return (lower + upper) / 2
+}
@@ -4469,6 +4469,16 @@ func (z *MetricsOptions) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.Type = MetricType(zb0002)
 			}
+		case "Exclude":
+			{
+				var zb0003 uint32
+				zb0003, err = dc.ReadUint32()
+				if err != nil {
+					err = msgp.WrapError(err, "Exclude")
+					return
+				}
+				z.Exclude = MetricType(zb0003)
+			}
 		case "N":
 			z.N, err = dc.ReadInt()
 			if err != nil {
@@ -4482,16 +4492,16 @@ func (z *MetricsOptions) DecodeMsg(dc *msgp.Reader) (err error) {
 				return
 			}
 		case "Hosts":
-			var zb0003 uint32
-			zb0003, err = dc.ReadArrayHeader()
+			var zb0004 uint32
+			zb0004, err = dc.ReadArrayHeader()
 			if err != nil {
 				err = msgp.WrapError(err, "Hosts")
 				return
 			}
-			if cap(z.Hosts) >= int(zb0003) {
-				z.Hosts = (z.Hosts)[:zb0003]
+			if cap(z.Hosts) >= int(zb0004) {
+				z.Hosts = (z.Hosts)[:zb0004]
 			} else {
-				z.Hosts = make([]string, zb0003)
+				z.Hosts = make([]string, zb0004)
 			}
 			for za0001 := range z.Hosts {
 				z.Hosts[za0001], err = dc.ReadString()
@@ -4507,16 +4517,16 @@ func (z *MetricsOptions) DecodeMsg(dc *msgp.Reader) (err error) {
 				return
 			}
 		case "Disks":
-			var zb0004 uint32
-			zb0004, err = dc.ReadArrayHeader()
+			var zb0005 uint32
+			zb0005, err = dc.ReadArrayHeader()
 			if err != nil {
 				err = msgp.WrapError(err, "Disks")
 				return
 			}
-			if cap(z.Disks) >= int(zb0004) {
-				z.Disks = (z.Disks)[:zb0004]
+			if cap(z.Disks) >= int(zb0005) {
+				z.Disks = (z.Disks)[:zb0005]
 			} else {
-				z.Disks = make([]string, zb0004)
+				z.Disks = make([]string, zb0005)
 			}
 			for za0002 := range z.Disks {
 				z.Disks[za0002], err = dc.ReadString()
@@ -4556,9 +4566,9 @@ func (z *MetricsOptions) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *MetricsOptions) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 9
+	// map header, size 10
 	// write "Type"
-	err = en.Append(0x89, 0xa4, 0x54, 0x79, 0x70, 0x65)
+	err = en.Append(0x8a, 0xa4, 0x54, 0x79, 0x70, 0x65)
 	if err != nil {
 		return
 	}
@@ -4567,6 +4577,16 @@ func (z *MetricsOptions) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Type")
 		return
 	}
+	// write "Exclude"
+	err = en.Append(0xa7, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint32(uint32(z.Exclude))
+	if err != nil {
+		err = msgp.WrapError(err, "Exclude")
+		return
+	}
 	// write "N"
 	err = en.Append(0xa1, 0x4e)
 	if err != nil {
@@ -4667,10 +4687,13 @@ func (z *MetricsOptions) EncodeMsg(en *msgp.Writer) (err error) {
 // MarshalMsg implements msgp.Marshaler
 func (z *MetricsOptions) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 9
+	// map header, size 10
 	// string "Type"
-	o = append(o, 0x89, 0xa4, 0x54, 0x79, 0x70, 0x65)
+	o = append(o, 0x8a, 0xa4, 0x54, 0x79, 0x70, 0x65)
 	o = msgp.AppendUint32(o, uint32(z.Type))
+	// string "Exclude"
+	o = append(o, 0xa7, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65)
+	o = msgp.AppendUint32(o, uint32(z.Exclude))
 	// string "N"
 	o = append(o, 0xa1, 0x4e)
 	o = msgp.AppendInt(o, z.N)
@@ -4732,6 +4755,16 @@ func (z *MetricsOptions) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.Type = MetricType(zb0002)
 			}
+		case "Exclude":
+			{
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadUint32Bytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Exclude")
+					return
+				}
+				z.Exclude = MetricType(zb0003)
+			}
 		case "N":
 			z.N, bts, err = msgp.ReadIntBytes(bts)
 			if err != nil {
@@ -4745,16 +4778,16 @@ func (z *MetricsOptions) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		case "Hosts":
-			var zb0003 uint32
-			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "Hosts")
 				return
 			}
-			if cap(z.Hosts) >= int(zb0003) {
-				z.Hosts = (z.Hosts)[:zb0003]
+			if cap(z.Hosts) >= int(zb0004) {
+				z.Hosts = (z.Hosts)[:zb0004]
 			} else {
-				z.Hosts = make([]string, zb0003)
+				z.Hosts = make([]string, zb0004)
 			}
 			for za0001 := range z.Hosts {
 				z.Hosts[za0001], bts, err = msgp.ReadStringBytes(bts)
@@ -4770,16 +4803,16 @@ func (z *MetricsOptions) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		case "Disks":
-			var zb0004 uint32
-			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "Disks")
 				return
 			}
-			if cap(z.Disks) >= int(zb0004) {
-				z.Disks = (z.Disks)[:zb0004]
+			if cap(z.Disks) >= int(zb0005) {
+				z.Disks = (z.Disks)[:zb0005]
 			} else {
-				z.Disks = make([]string, zb0004)
+				z.Disks = make([]string, zb0005)
 			}
 			for za0002 := range z.Disks {
 				z.Disks[za0002], bts, err = msgp.ReadStringBytes(bts)
@@ -4820,7 +4853,7 @@ func (z *MetricsOptions) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *MetricsOptions) Msgsize() (s int) {
-	s = 1 + 5 + msgp.Uint32Size + 2 + msgp.IntSize + 9 + msgp.DurationSize + 6 + msgp.ArrayHeaderSize
+	s = 1 + 5 + msgp.Uint32Size + 8 + msgp.Uint32Size + 2 + msgp.IntSize + 9 + msgp.DurationSize + 6 + msgp.ArrayHeaderSize
 	for za0001 := range z.Hosts {
 		s += msgp.StringPrefixSize + len(z.Hosts[za0001])
 	}
@@ -0,0 +1,170 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WritePrometheus writes the disk, RPC and replication resync metrics in 'm'
+// to w using the Prometheus text exposition format.
+// The provided labels are added to every series written.
+// Sections that are not present in 'm' are skipped.
+func (m Metrics) WritePrometheus(w io.Writer, labels map[string]string) error {
+	p := promWriter{w: bufio.NewWriter(w), labels: labels}
+	if d := m.Disk; d != nil {
+		p.gauge("minio_disk_drives", "Number of drives", float64(d.NDisks))
+		p.gauge("minio_disk_drives_offline", "Number of offline drives", float64(d.Offline))
+		p.gauge("minio_disk_drives_healing", "Number of healing drives", float64(d.Healing))
+		p.counterMap("minio_disk_ops_total", "Number of drive operations since server start", "op", d.LifeTimeOps)
+
+		st := d.IOStats
+		p.counter("minio_disk_read_ios_total", "Number of read I/Os processed", st.ReadIOs)
+		p.counter("minio_disk_read_merges_total", "Number of read I/Os merged with in-queue I/Os", st.ReadMerges)
+		p.counter("minio_disk_read_sectors_total", "Number of sectors read", st.ReadSectors)
+		p.counter("minio_disk_read_ticks_total", "Total wait time for read requests in milliseconds", st.ReadTicks)
+		p.counter("minio_disk_write_ios_total", "Number of write I/Os processed", st.WriteIOs)
+		p.counter("minio_disk_write_merges_total", "Number of write I/Os merged with in-queue I/Os", st.WriteMerges)
+		p.counter("minio_disk_write_sectors_total", "Number of sectors written", st.WriteSectors)
+		p.counter("minio_disk_write_ticks_total", "Total wait time for write requests in milliseconds", st.WriteTicks)
+		p.gauge("minio_disk_current_ios", "Number of I/Os currently in flight", float64(st.CurrentIOs))
+		p.counter("minio_disk_total_ticks_total", "Total time the drives have been active in milliseconds", st.TotalTicks)
+		p.counter("minio_disk_req_ticks_total", "Total wait time for all requests in milliseconds", st.ReqTicks)
+		p.counter("minio_disk_discard_ios_total", "Number of discard I/Os processed", st.DiscardIOs)
+		p.counter("minio_disk_discard_merges_total", "Number of discard I/Os merged with in-queue I/Os", st.DiscardMerges)
+		p.counter("minio_disk_discard_sectors_total", "Number of sectors discarded", st.DiscardSectors)
+		p.counter("minio_disk_discard_ticks_total", "Total wait time for discard requests in milliseconds", st.DiscardTicks)
+		p.counter("minio_disk_flush_ios_total", "Number of flush I/Os processed", st.FlushIOs)
+		p.counter("minio_disk_flush_ticks_total", "Total wait time for flush requests in milliseconds", st.FlushTicks)
+	}
+	if r := m.RPC; r != nil {
+		p.gauge("minio_rpc_connected", "Number of connected RPC peers", float64(r.Connected))
+		p.gauge("minio_rpc_disconnected", "Number of disconnected RPC peers", float64(r.Disconnected))
+		p.counter("minio_rpc_reconnects_total", "Number of RPC reconnects", uint64(r.ReconnectCount))
+		p.gauge("minio_rpc_outgoing_streams", "Number of outgoing RPC streams", float64(r.OutgoingStreams))
+		p.gauge("minio_rpc_incoming_streams", "Number of incoming RPC streams", float64(r.IncomingStreams))
+		p.counter("minio_rpc_outgoing_bytes_total", "Number of bytes sent over RPC", uint64(r.OutgoingBytes))
+		p.counter("minio_rpc_incoming_bytes_total", "Number of bytes received over RPC", uint64(r.IncomingBytes))
+		p.counter("minio_rpc_outgoing_messages_total", "Number of messages sent over RPC", uint64(r.OutgoingMessages))
+		p.counter("minio_rpc_incoming_messages_total", "Number of messages received over RPC", uint64(r.IncomingMessages))
+		p.gauge("minio_rpc_out_queue", "Number of queued outgoing RPC messages", float64(r.OutQueue))
+		p.gauge("minio_rpc_last_ping_milliseconds", "Last RPC ping duration in milliseconds", r.LastPingMS)
+		p.gauge("minio_rpc_max_ping_milliseconds", "Maximum RPC ping duration in milliseconds", r.MaxPingDurMS)
+	}
+	if s := m.SiteResync; s != nil {
+		p.gauge("minio_replication_resync_buckets", "Number of buckets being resynced", float64(s.NumBuckets))
+		p.counter("minio_replication_resync_replicated_bytes_total", "Number of bytes replicated by the resync", uint64(s.ReplicatedSize))
+		p.counter("minio_replication_resync_replicated_objects_total", "Number of objects replicated by the resync", uint64(s.ReplicatedCount))
+		p.counter("minio_replication_resync_failed_bytes_total", "Number of bytes that failed to replicate during the resync", uint64(s.FailedSize))
+		p.counter("minio_replication_resync_failed_objects_total", "Number of objects that failed to replicate during the resync", uint64(s.FailedCount))
+	}
+	if p.err != nil {
+		return p.err
+	}
+	return p.w.Flush()
+}
+
+// promWriter writes Prometheus text format and keeps the first error.
+type promWriter struct {
+	w      *bufio.Writer
+	labels map[string]string
+	err    error
+}
+
+func (p *promWriter) header(name, help, typ string) {
+	p.write("# HELP " + name + " " + help + "\n")
+	p.write("# TYPE " + name + " " + typ + "\n")
+}
+
+func (p *promWriter) series(name, labelName, labelValue, value string) {
+	keys := make([]string, 0, len(p.labels)+1)
+	for k := range p.labels {
+		if k != labelName {
+			keys = append(keys, k)
+		}
+	}
+	if labelName != "" {
+		keys = append(keys, labelName)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString(name)
+	for i, k := range keys {
+		if i == 0 {
+			sb.WriteByte('{')
+		} else {
+			sb.WriteByte(',')
+		}
+		v := p.labels[k]
+		if k == labelName {
+			v = labelValue
+		}
+		sb.WriteString(k)
+		sb.WriteString(`="`)
+		sb.WriteString(promLabelEscaper.Replace(v))
+		sb.WriteByte('"')
+	}
+	if len(keys) > 0 {
+		sb.WriteByte('}')
+	}
+	sb.WriteByte(' ')
+	sb.WriteString(value)
+	sb.WriteByte('\n')
+	p.write(sb.String())
+}
+
+func (p *promWriter) gauge(name, help string, v float64) {
+	p.header(name, help, "gauge")
+	p.series(name, "", "", strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+func (p *promWriter) counter(name, help string, v uint64) {
+	p.header(name, help, "counter")
+	p.series(name, "", "", strconv.FormatUint(v, 10))
+}
+
+func (p *promWriter) counterMap(name, help, labelName string, m map[string]uint64) {
+	if len(m) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	p.header(name, help, "counter")
+	for _, k := range keys {
+		p.series(name, labelName, k, strconv.FormatUint(m[k], 10))
+	}
+}
+
+func (p *promWriter) write(s string) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = p.w.WriteString(s)
+}
+
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
@@ -0,0 +1,118 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func TestMetricsWritePrometheus(t *testing.T) {
+	m := Metrics{
+		Disk: &DiskMetric{
+			NDisks:  16,
+			Offline: 1,
+			Healing: 2,
+			LifeTimeOps: map[string]uint64{
+				"ReadAll":   100,
+				"WriteAll":  50,
+				"DeleteVol": 3,
+			},
+			IOStats: DiskIOStats{
+				ReadIOs:        1,
+				ReadMerges:     2,
+				ReadSectors:    3,
+				ReadTicks:      4,
+				WriteIOs:       5,
+				WriteMerges:    6,
+				WriteSectors:   7,
+				WriteTicks:     8,
+				CurrentIOs:     9,
+				TotalTicks:     10,
+				ReqTicks:       11,
+				DiscardIOs:     12,
+				DiscardMerges:  13,
+				DiscardSectors: 14,
+				DiscardTicks:   15,
+				FlushIOs:       16,
+				FlushTicks:     17,
+			},
+		},
+		RPC: &RPCMetrics{
+			Connected:        10,
+			ReconnectCount:   2,
+			Disconnected:     1,
+			OutgoingStreams:  20,
+			IncomingStreams:  21,
+			OutgoingBytes:    1 << 30,
+			IncomingBytes:    1 << 31,
+			OutgoingMessages: 1000,
+			IncomingMessages: 2000,
+			OutQueue:         5,
+			LastPingMS:       1.5,
+			MaxPingDurMS:     12.25,
+		},
+		SiteResync: &SiteResyncMetrics{
+			NumBuckets:      4,
+			ReplicatedSize:  4096,
+			ReplicatedCount: 40,
+			FailedSize:      512,
+			FailedCount:     2,
+		},
+	}
+	labels := map[string]string{
+		"server":  "node1:9000",
+		"cluster": `my "cluster"`,
+	}
+
+	var buf bytes.Buffer
+	if err := m.WritePrometheus(&buf, labels); err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "metrics.prom")
+	if *updateGolden {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("output does not match %s:\n%s", golden, buf.String())
+	}
+}
+
+func TestMetricsWritePrometheusEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (Metrics{}).WritePrometheus(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got:\n%s", buf.String())
+	}
+}
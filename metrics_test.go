@@ -0,0 +1,656 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"runtime/metrics"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMetricsOptionsTypes(t *testing.T) {
+	tests := []struct {
+		opts MetricsOptions
+		want MetricType
+	}{
+		{opts: MetricsOptions{}, want: MetricsAll},
+		{opts: MetricsOptions{Type: MetricsDisk | MetricsOS}, want: MetricsDisk | MetricsOS},
+		{opts: MetricsOptions{Exclude: MetricsScanner}, want: MetricsAll &^ MetricsScanner},
+		{opts: MetricsOptions{Type: MetricsDisk | MetricsScanner, Exclude: MetricsScanner}, want: MetricsDisk},
+		{opts: MetricsOptions{Type: MetricsDisk, Exclude: MetricsDisk}, want: 0},
+		{opts: MetricsOptions{Type: MetricsAll, Exclude: MetricsScanner | MetricsRuntime}, want: MetricsAll &^ (MetricsScanner | MetricsRuntime)},
+	}
+	for i, test := range tests {
+		got := test.opts.queryValues().Get("types")
+		want := strconv.FormatUint(uint64(test.want), 10)
+		if got != want {
+			t.Errorf("test %d: expected types=%s, got types=%s", i, want, got)
+		}
+	}
+}
+
+func TestMetricsContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Send a single sample, then hang until the client goes away.
+		json.NewEncoder(w).Encode(RealtimeMetrics{Hosts: []string{"node1:9000"}})
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adm, err := New(u.Host, "minioadmin", "minioadmin", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var samples int
+	err = adm.Metrics(ctx, MetricsOptions{}, func(RealtimeMetrics) {
+		samples++
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+	if samples != 1 {
+		t.Errorf("expected 1 sample, got %d", samples)
+	}
+}
+
+func TestMetricsNilOutput(t *testing.T) {
+	adm, err := New("localhost:9000", "minioadmin", "minioadmin", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := adm.Metrics(context.Background(), MetricsOptions{}, nil); err == nil {
+		t.Fatal("expected error for nil output function")
+	}
+}
+
+func TestScannerMetricsMergePerBucketStats(t *testing.T) {
+	now := time.Now()
+	host1 := ScannerMetrics{
+		PerBucketStats: map[string][]BucketScanInfo{
+			"bucket": {
+				{Pool: 0, Set: 0, Cycle: 1, LastUpdate: now},
+				{Pool: 0, Set: 1, Cycle: 1, LastUpdate: now.Add(-time.Minute)},
+			},
+		},
+	}
+	host2 := ScannerMetrics{
+		PerBucketStats: map[string][]BucketScanInfo{
+			"bucket": {
+				{Pool: 0, Set: 1, Cycle: 2, LastUpdate: now},
+				{Pool: 1, Set: 0, Cycle: 3, LastUpdate: now},
+			},
+			"other": {
+				{Pool: 0, Set: 0, Cycle: 4, LastUpdate: now},
+			},
+		},
+	}
+
+	var merged ScannerMetrics
+	merged.Merge(&host1)
+	merged.Merge(&host2)
+
+	want := []BucketScanInfo{
+		{Pool: 0, Set: 0, Cycle: 1, LastUpdate: now},
+		{Pool: 0, Set: 1, Cycle: 2, LastUpdate: now},
+		{Pool: 1, Set: 0, Cycle: 3, LastUpdate: now},
+	}
+	if got := merged.PerBucketStats["bucket"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if got := merged.PerBucketStats["other"]; len(got) != 1 || got[0].Cycle != 4 {
+		t.Errorf("unexpected stats for other bucket: %+v", got)
+	}
+	if got := host1.PerBucketStats["bucket"]; len(got) != 2 || got[1].Cycle != 1 {
+		t.Errorf("merge modified its input: %+v", got)
+	}
+}
+
+func TestDiskMetricUtilization(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC)
+	prev := DiskMetric{CollectedAt: t0, NDisks: 4, IOStats: DiskIOStats{TotalTicks: 500000}}
+	tests := []struct {
+		name string
+		disk DiskMetric
+		want float64
+	}{
+		{
+			name: "no drives",
+			disk: DiskMetric{CollectedAt: t0.Add(time.Minute), IOStats: DiskIOStats{TotalTicks: 501000}},
+			want: 0,
+		},
+		{
+			name: "no ticks",
+			disk: DiskMetric{CollectedAt: t0.Add(time.Minute), NDisks: 4, IOStats: DiskIOStats{TotalTicks: 500000}},
+			want: 0,
+		},
+		{
+			name: "not collected after prev",
+			disk: DiskMetric{CollectedAt: t0, NDisks: 4, IOStats: DiskIOStats{TotalTicks: 501000}},
+			want: 0,
+		},
+		{
+			name: "counters reset",
+			disk: DiskMetric{CollectedAt: t0.Add(time.Minute), NDisks: 4, IOStats: DiskIOStats{TotalTicks: 1000}},
+			want: 0,
+		},
+		{
+			// 4 drives busy for a total of 2 minutes during one minute.
+			name: "one minute sample",
+			disk: DiskMetric{CollectedAt: t0.Add(time.Minute), NDisks: 4, IOStats: DiskIOStats{TotalTicks: 620000}},
+			want: 50,
+		},
+	}
+	for _, test := range tests {
+		if got := test.disk.Utilization(prev); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestDiskIOStatsSub(t *testing.T) {
+	cur := DiskIOStats{ReadIOs: 15, WriteSectors: 100, CurrentIOs: 3, TotalTicks: 5}
+	prev := DiskIOStats{ReadIOs: 10, WriteSectors: 40, CurrentIOs: 7, TotalTicks: 9}
+	want := DiskIOStats{ReadIOs: 5, WriteSectors: 60, CurrentIOs: 3}
+	if got := cur.Sub(prev); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+// testJSONRoundTrip marshals in, unmarshals the result into out
+// and checks that out is equal to in.
+func testJSONRoundTrip(t *testing.T, in, out interface{}) {
+	t.Helper()
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		t.Fatal(err)
+	}
+	if got := reflect.ValueOf(out).Elem().Interface(); !reflect.DeepEqual(got, in) {
+		t.Errorf("JSON round trip mismatch:\nwant %+v\ngot  %+v\njson %s", in, got, b)
+	}
+}
+
+func TestDiskMetricJSONRoundTrip(t *testing.T) {
+	in := DiskMetric{
+		CollectedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		NDisks:      4,
+		LifeTimeOps: map[string]uint64{"ReadAll": 10},
+		IOStats: DiskIOStats{
+			ReadIOs:        1,
+			ReadMerges:     2,
+			ReadSectors:    3,
+			ReadTicks:      4,
+			WriteIOs:       5,
+			WriteMerges:    6,
+			WriteSectors:   7,
+			WriteTicks:     8,
+			CurrentIOs:     9,
+			TotalTicks:     10,
+			ReqTicks:       11,
+			DiscardIOs:     12,
+			DiscardMerges:  13,
+			DiscardSectors: 14,
+			DiscardTicks:   15,
+			FlushIOs:       16,
+			FlushTicks:     17,
+		},
+	}
+	var out DiskMetric
+	testJSONRoundTrip(t, in, &out)
+	if out.IOStats.WriteSectors != 7 || out.IOStats.DiscardSectors != 14 {
+		t.Errorf("sector counters did not survive round trip: %+v", out.IOStats)
+	}
+}
+
+func TestBatchJobMetricsFilterByType(t *testing.T) {
+	collected := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := BatchJobMetrics{
+		CollectedAt: collected,
+		Jobs: map[string]JobMetric{
+			"r1": {JobID: "r1", JobType: string(BatchJobReplicate)},
+			"r2": {JobID: "r2", JobType: string(BatchJobReplicate)},
+			"k1": {JobID: "k1", JobType: string(BatchJobKeyRotate)},
+			"e1": {JobID: "e1", JobType: string(BatchJobExpire)},
+			"c1": {JobID: "c1", JobType: "catalog"},
+		},
+	}
+
+	tests := []struct {
+		jobType string
+		want    []string
+	}{
+		{jobType: string(BatchJobReplicate), want: []string{"r1", "r2"}},
+		{jobType: string(BatchJobKeyRotate), want: []string{"k1"}},
+		{jobType: string(BatchJobExpire), want: []string{"e1"}},
+		{jobType: "catalog", want: []string{"c1"}},
+		{jobType: "unknown", want: []string{}},
+	}
+	for _, test := range tests {
+		got := m.FilterByType(test.jobType)
+		if !got.CollectedAt.Equal(collected) {
+			t.Errorf("%s: expected CollectedAt %v, got %v", test.jobType, collected, got.CollectedAt)
+		}
+		if got.Jobs == nil {
+			t.Fatalf("%s: expected non-nil jobs map", test.jobType)
+		}
+		ids := make([]string, 0, len(got.Jobs))
+		for id, job := range got.Jobs {
+			if job.JobType != test.jobType {
+				t.Errorf("%s: unexpected job %+v", test.jobType, job)
+			}
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		if !reflect.DeepEqual(ids, test.want) {
+			t.Errorf("%s: expected jobs %v, got %v", test.jobType, test.want, ids)
+		}
+	}
+	if len(m.Jobs) != 5 {
+		t.Errorf("filter modified the input: %v", m.Jobs)
+	}
+}
+
+func TestJobMetricProgress(t *testing.T) {
+	tests := []struct {
+		name         string
+		job          JobMetric
+		done, failed int64
+		ok           bool
+	}{
+		{
+			name: "replicate",
+			job:  JobMetric{Replicate: &ReplicateInfo{Objects: 10, ObjectsFailed: 1}},
+			done: 10, failed: 1, ok: true,
+		},
+		{
+			name: "keyrotate",
+			job:  JobMetric{KeyRotate: &KeyRotationInfo{Objects: 20, ObjectsFailed: 2}},
+			done: 20, failed: 2, ok: true,
+		},
+		{
+			name: "expire",
+			job:  JobMetric{Expired: &ExpirationInfo{Objects: 30, ObjectsFailed: 3}},
+			done: 30, failed: 3, ok: true,
+		},
+		{
+			name: "empty",
+			job:  JobMetric{JobType: string(BatchJobReplicate)},
+		},
+	}
+	for _, test := range tests {
+		done, failed, ok := test.job.Progress()
+		if done != test.done || failed != test.failed || ok != test.ok {
+			t.Errorf("%s: expected (%d, %d, %v), got (%d, %d, %v)",
+				test.name, test.done, test.failed, test.ok, done, failed, ok)
+		}
+	}
+}
+
+func TestMetricsPartialResults(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		enc := json.NewEncoder(w)
+		enc.Encode(RealtimeMetrics{
+			Errors: []string{"node2:9000: dial tcp 10.0.0.2:9000: connect: connection refused"},
+			Hosts:  []string{"node1:9000"},
+			ByHost: map[string]Metrics{
+				"node1:9000": {Mem: &MemMetrics{Info: MemInfo{Total: 1 << 30}}},
+			},
+		})
+		enc.Encode(RealtimeMetrics{Final: true})
+	})
+
+	var got RealtimeMetrics
+	err := adm.Metrics(context.Background(), MetricsOptions{Type: MetricsMem}, func(m RealtimeMetrics) {
+		got.Merge(&m)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := got.ByHost["node1:9000"]; !ok || m.Mem == nil || m.Mem.Info.Total != 1<<30 {
+		t.Errorf("expected metrics of node1:9000, got %+v", got.ByHost)
+	}
+	if want := []string{"node2:9000"}; !reflect.DeepEqual(got.FailedHosts(), want) {
+		t.Errorf("expected failed hosts %q, got %q", want, got.FailedHosts())
+	}
+}
+
+func TestRealtimeMetricsFailedHosts(t *testing.T) {
+	r := RealtimeMetrics{Errors: []string{
+		"node3:9000: disk not found",
+		"node1:9000: context deadline exceeded",
+		"node3:9000: drive offline",
+		"unknown error",
+	}}
+	if want := []string{"node1:9000", "node3:9000"}; !reflect.DeepEqual(r.FailedHosts(), want) {
+		t.Errorf("expected failed hosts %q, got %q", want, r.FailedHosts())
+	}
+	if hosts := (RealtimeMetrics{}).FailedHosts(); hosts != nil {
+		t.Errorf("expected no failed hosts, got %q", hosts)
+	}
+}
+
+func TestDriveLatencies(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !r.URL.Query().Has("by-disk") {
+			t.Errorf("expected by-disk query param, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"hosts":["node1:9000"],"by_disk":{
+			"node1:9000/data2":{"n_disks":1,"last_minute":{"operations":{
+				"ReadFile":{"count":2,"acc_time_ns":4000000,"min_ns":1000000,"max_ns":3000000},
+				"CreateFile":{"count":1,"acc_time_ns":8000000,"min_ns":8000000,"max_ns":8000000}}}},
+			"node1:9000/data1":{"n_disks":1,"last_minute":{"operations":{
+				"ReadVersion":{"count":1,"acc_time_ns":1000000,"min_ns":1000000,"max_ns":1000000},
+				"ReadXL":{"count":3,"acc_time_ns":9000000,"min_ns":2000000,"max_ns":5000000},
+				"WriteAll":{"count":2,"acc_time_ns":6000000,"min_ns":2000000,"max_ns":4000000},
+				"StatVol":{"count":10,"acc_time_ns":1000000,"min_ns":100000,"max_ns":100000}}}}
+		},"final":true}`))
+	})
+
+	latencies, err := adm.DriveLatencies(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []DriveLatency{
+		{
+			Drive: "node1:9000/data1",
+			Read:  TimedAction{Count: 4, AccTime: 10000000, MinTime: 1000000, MaxTime: 5000000},
+			Write: TimedAction{Count: 2, AccTime: 6000000, MinTime: 2000000, MaxTime: 4000000},
+		},
+		{
+			Drive: "node1:9000/data2",
+			Read:  TimedAction{Count: 2, AccTime: 4000000, MinTime: 1000000, MaxTime: 3000000},
+			Write: TimedAction{Count: 1, AccTime: 8000000, MinTime: 8000000, MaxTime: 8000000},
+		},
+	}
+	if !reflect.DeepEqual(latencies, want) {
+		t.Errorf("expected %+v, got %+v", want, latencies)
+	}
+	if avg := latencies[0].Read.Avg(); avg != 2500*time.Microsecond {
+		t.Errorf("expected average read latency 2.5ms, got %v", avg)
+	}
+}
+
+func TestDrivePerformanceOutliers(t *testing.T) {
+	byDisk := make(map[string]DiskMetric)
+	for i := 1; i <= 8; i++ {
+		latency := uint64(time.Millisecond)
+		if i == 5 {
+			latency = uint64(20 * time.Millisecond)
+		}
+		var m DiskMetric
+		m.LastMinute.Operations = map[string]TimedAction{
+			"ReadXL":   {Count: 10, AccTime: 10 * latency, MinTime: latency, MaxTime: latency},
+			"WriteAll": {Count: 5, AccTime: 5 * latency, MinTime: latency, MaxTime: latency},
+		}
+		byDisk[fmt.Sprintf("node1:9000/data%d", i)] = m
+	}
+	// A drive without reads or writes is left out.
+	var idle DiskMetric
+	idle.LastMinute.Operations = map[string]TimedAction{"StatVol": {Count: 1, AccTime: uint64(time.Second)}}
+	byDisk["node1:9000/data9"] = idle
+
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(RealtimeMetrics{ByDisk: byDisk, Final: true})
+	})
+
+	outliers, err := adm.DrivePerformanceOutliers(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outliers) != 1 {
+		t.Fatalf("expected 1 outlier, got %+v", outliers)
+	}
+	o := outliers[0]
+	if o.Drive != "node1:9000/data5" || o.Latency != 20*time.Millisecond || o.Mean != 3375*time.Microsecond {
+		t.Errorf("unexpected outlier %+v", o)
+	}
+	if o.StdDevs < 2.6 || o.StdDevs > 2.7 {
+		t.Errorf("expected about 2.65 standard deviations, got %v", o.StdDevs)
+	}
+
+	if outliers, _ = adm.DrivePerformanceOutliers(context.Background(), 3); len(outliers) != 0 {
+		t.Errorf("expected no outliers above 3 standard deviations, got %+v", outliers)
+	}
+}
+
+func TestMetricsDiskThroughput(t *testing.T) {
+	var m, prev Metrics
+	if r, w := m.DiskThroughput(prev); r != 0 || w != 0 {
+		t.Errorf("expected no throughput without disk metrics, got %v, %v", r, w)
+	}
+
+	// Two hosts reading 60 MiB and writing 30 MiB in total over one minute.
+	t0 := time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC)
+	prev.Merge(&Metrics{Disk: &DiskMetric{CollectedAt: t0, NDisks: 2, IOStats: DiskIOStats{ReadSectors: 1000, WriteSectors: 2000}}})
+	prev.Merge(&Metrics{Disk: &DiskMetric{CollectedAt: t0, NDisks: 2, IOStats: DiskIOStats{ReadSectors: 3000, WriteSectors: 4000}}})
+	m.Merge(&Metrics{Disk: &DiskMetric{CollectedAt: t0.Add(time.Minute), NDisks: 2, IOStats: DiskIOStats{ReadSectors: 82920, WriteSectors: 42960}}})
+	m.Merge(&Metrics{Disk: &DiskMetric{CollectedAt: t0.Add(time.Minute), NDisks: 2, IOStats: DiskIOStats{ReadSectors: 43960, WriteSectors: 24480}}})
+	readBps, writeBps := m.DiskThroughput(prev)
+	if readBps != 1<<20 || writeBps != 512<<10 {
+		t.Errorf("expected 1 MiB/s read and 512 KiB/s write, got %v, %v", readBps, writeBps)
+	}
+	if r, w := prev.DiskThroughput(m); r != 0 || w != 0 {
+		t.Errorf("expected no throughput against a later sample, got %v, %v", r, w)
+	}
+}
+
+func TestDiskMetricMergeIOStats(t *testing.T) {
+	d := DiskMetric{NDisks: 1, IOStats: DiskIOStats{ReadIOs: 1, ReadSectors: 10, CurrentIOs: 2, TotalTicks: 100}}
+	d.Merge(&DiskMetric{NDisks: 2, IOStats: DiskIOStats{ReadIOs: 2, WriteSectors: 20, CurrentIOs: 3, TotalTicks: 50}})
+	want := DiskIOStats{ReadIOs: 3, ReadSectors: 10, WriteSectors: 20, CurrentIOs: 5, TotalTicks: 150}
+	if d.NDisks != 3 || d.IOStats != want {
+		t.Errorf("expected 3 drives with %+v, got %d with %+v", want, d.NDisks, d.IOStats)
+	}
+	d.Merge(nil)
+	if d.IOStats != want {
+		t.Errorf("merging nil changed IO stats: %+v", d.IOStats)
+	}
+}
+
+func TestRealtimeMetricsSummarize(t *testing.T) {
+	disk := &DiskMetric{NDisks: 48, Healing: 2}
+	disk.LastMinute.Operations = map[string]TimedAction{
+		"ReadFile":  {Count: 54000},
+		"WriteAll":  {Count: 18000},
+		"StatInfoF": {Count: 0},
+	}
+	hosts := make([]string, 12)
+	for i := range hosts {
+		hosts[i] = fmt.Sprintf("node%d:9000", i+1)
+	}
+	tests := []struct {
+		name string
+		m    RealtimeMetrics
+		want string
+	}{
+		{
+			name: "full",
+			m:    RealtimeMetrics{Hosts: hosts, Aggregated: Metrics{Disk: disk}},
+			want: "12 nodes, 48 drives (2 healing), 1.2k drive ops/s",
+		},
+		{
+			name: "unreachable and offline",
+			m: RealtimeMetrics{
+				Hosts:      hosts[:3],
+				Errors:     []string{"node4:9000: connection refused"},
+				Aggregated: Metrics{Disk: &DiskMetric{NDisks: 12, Healing: 1, Offline: 4}},
+			},
+			want: "3 nodes (1 unreachable), 12 drives (1 healing, 4 offline), 0.0 drive ops/s",
+		},
+		{
+			name: "no drive metrics",
+			m:    RealtimeMetrics{Hosts: hosts[:1]},
+			want: "1 node",
+		},
+	}
+	for _, test := range tests {
+		if got := test.m.Summarize(); got != test.want {
+			t.Errorf("%s: expected %q, got %q", test.name, test.want, got)
+		}
+	}
+}
+
+func TestMemPressure(t *testing.T) {
+	const gib = 1 << 30
+	byHost := map[string]Metrics{
+		"node1:9000": {Mem: &MemMetrics{Info: MemInfo{Total: 64 * gib, Available: 48 * gib, Limit: 64 * gib}}},
+		"node2:9000": {Mem: &MemMetrics{Info: MemInfo{
+			Total:          64 * gib,
+			Available:      34 * gib,
+			Limit:          32 * gib,
+			SwapSpaceTotal: 8 * gib,
+			SwapSpaceFree:  2 * gib,
+		}}},
+		"node3:9000": {},
+	}
+	nodes := memPressure(byHost)
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %+v", nodes)
+	}
+	want := []NodeMemPressure{
+		{Host: "node1:9000", UsedPercent: 25, Limit: 64 * gib, LimitUsedPercent: 25},
+		{Host: "node2:9000", UsedPercent: 46.875, SwapUsedPercent: 75, Limit: 32 * gib, LimitUsedPercent: 93.75},
+	}
+	if !reflect.DeepEqual(nodes, want) {
+		t.Errorf("expected %+v, got %+v", want, nodes)
+	}
+	if nodes[0].Critical() {
+		t.Errorf("%s: expected no critical memory pressure", nodes[0].Host)
+	}
+	if !nodes[1].Critical() {
+		t.Errorf("%s: expected critical memory pressure near its cgroup limit", nodes[1].Host)
+	}
+}
+
+func TestRuntimeMetricsSummary(t *testing.T) {
+	m := RuntimeMetrics{
+		UintMetrics: map[string]uint64{
+			"/sched/goroutines:goroutines":       1500,
+			"/sched/gomaxprocs:threads":          16,
+			"/memory/classes/heap/objects:bytes": 512 << 20,
+			"/gc/heap/objects:objects":           2000000,
+			"/gc/cycles/total:gc-cycles":         42,
+		},
+		FloatMetrics: map[string]float64{
+			"/cpu/classes/gc/total:cpu-seconds": 12.5,
+		},
+		HistMetrics: map[string]metrics.Float64Histogram{
+			"/gc/pauses:seconds": {
+				Counts:  []uint64{0, 3, 1},
+				Buckets: []float64{math.Inf(-1), 0.0001, 0.0003, math.Inf(1)},
+			},
+		},
+		N: 1,
+	}
+	want := RuntimeSummary{
+		Goroutines:   1500,
+		GoMaxProcs:   16,
+		HeapInUse:    512 << 20,
+		LiveObjects:  2000000,
+		GCCycles:     42,
+		GCPauses:     4,
+		GCCPUSeconds: 12.5,
+		// Three pauses of about 200µs and one of at least 300µs.
+		GCPauseAvg: 225 * time.Microsecond,
+	}
+	got := m.Summary()
+	if d := got.GCPauseAvg - want.GCPauseAvg; d > time.Nanosecond || d < -time.Nanosecond {
+		t.Errorf("expected average GC pause %v, got %v", want.GCPauseAvg, got.GCPauseAvg)
+	}
+	got.GCPauseAvg = want.GCPauseAvg
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if got := (RuntimeMetrics{}).Summary(); got != (RuntimeSummary{}) {
+		t.Errorf("expected an empty summary, got %+v", got)
+	}
+}
+
+func TestRuntimeMetricsGCPausePercentile(t *testing.T) {
+	m := RuntimeMetrics{
+		HistMetrics: map[string]metrics.Float64Histogram{
+			"/gc/pauses:seconds": {
+				// 90 pauses of 0-100µs, 9 of 100-200µs and 1 of 1ms or more.
+				Counts:  []uint64{90, 9, 0, 1},
+				Buckets: []float64{0, 0.0001, 0.0002, 0.001, math.Inf(1)},
+			},
+		},
+	}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{p: 0, want: 0},
+		{p: 45, want: 50 * time.Microsecond},
+		{p: 90, want: 100 * time.Microsecond},
+		{p: 99, want: 200 * time.Microsecond},
+		{p: 100, want: time.Millisecond},
+	}
+	for _, test := range tests {
+		got, ok := m.GCPausePercentile(test.p)
+		if !ok {
+			t.Errorf("p%v: expected a percentile", test.p)
+			continue
+		}
+		if d := got - test.want; d > time.Nanosecond || d < -time.Nanosecond {
+			t.Errorf("p%v: expected %v, got %v", test.p, test.want, got)
+		}
+	}
+	if got, ok := m.GCPauseP99(); !ok || got.Round(time.Microsecond) != 200*time.Microsecond {
+		t.Errorf("expected p99 of 200µs, got %v, %v", got, ok)
+	}
+
+	if _, ok := m.GCPausePercentile(101); ok {
+		t.Error("expected no percentile above 100")
+	}
+	if _, ok := (RuntimeMetrics{}).GCPauseP99(); ok {
+		t.Error("expected no percentile without a pause histogram")
+	}
+	empty := RuntimeMetrics{HistMetrics: map[string]metrics.Float64Histogram{
+		"/gc/pauses:seconds": {Counts: []uint64{0}, Buckets: []float64{0, 1}},
+	}}
+	if _, ok := empty.GCPauseP99(); ok {
+		t.Error("expected no percentile without pauses")
+	}
+}
@@ -22,6 +22,7 @@ package madmin
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"unicode"
 
@@ -261,6 +262,25 @@ func (c *SubsysConfig) Lookup(key string) (val string, present bool) {
 	return c.KV[idx].Value, true
 }
 
+// Merge returns a new config with the parameters of override layered over
+// those of c: parameters present in both are replaced by the override, in
+// their original position, and the remaining override parameters are added
+// at the end. If override is for another subsystem or target, the returned
+// config is a copy of c.
+func (c SubsysConfig) Merge(override SubsysConfig) SubsysConfig {
+	merged := SubsysConfig{SubSystem: c.SubSystem, Target: c.Target}
+	for _, ckv := range c.KV {
+		merged.AddConfigKV(ckv)
+	}
+	if override.SubSystem != c.SubSystem || override.Target != c.Target {
+		return merged
+	}
+	for _, ckv := range override.KV {
+		merged.AddConfigKV(ckv)
+	}
+	return merged
+}
+
 var (
 	ErrInvalidEnvVarLine = errors.New("expected env var line of the form `# MINIO_...=...`")
 	ErrInvalidConfigKV   = errors.New("expected config value in the format `key=value`")
@@ -507,3 +527,96 @@ func ParseServerConfigOutput(serverConfigOutput string) ([]SubsysConfig, error)
 
 	return res, nil
 }
+
+// ConfigChangeType is the kind of change of a config parameter.
+type ConfigChangeType string
+
+// Config change types
+const (
+	ConfigChangeAdded    ConfigChangeType = "added"
+	ConfigChangeRemoved  ConfigChangeType = "removed"
+	ConfigChangeModified ConfigChangeType = "modified"
+)
+
+// ConfigChange is a config parameter that differs between two server configs.
+// The default target of a subsystem is "" (empty string).
+type ConfigChange struct {
+	SubSystem string           `json:"subSystem"`
+	Target    string           `json:"target,omitempty"`
+	Key       string           `json:"key"`
+	Type      ConfigChangeType `json:"type"`
+	OldValue  string           `json:"oldValue,omitempty"`
+	NewValue  string           `json:"newValue,omitempty"`
+}
+
+// ConfigDiff - compares the server config outputs a and b, as returned by
+// `GetConfig`, and returns the config parameters added, removed or modified
+// in b, sorted by subsystem, target and key. The effective values are
+// compared, i.e. possibly overridden by an environment variable.
+func ConfigDiff(a, b []byte) ([]ConfigChange, error) {
+	aCfgs, err := ParseServerConfigOutput(string(a))
+	if err != nil {
+		return nil, err
+	}
+	bCfgs, err := ParseServerConfigOutput(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	type subsysTarget struct {
+		subSys, target string
+	}
+	bByTarget := make(map[subsysTarget]SubsysConfig, len(bCfgs))
+	for _, cfg := range bCfgs {
+		bByTarget[subsysTarget{cfg.SubSystem, cfg.Target}] = cfg
+	}
+
+	var changes []ConfigChange
+	seen := make(map[subsysTarget]bool, len(aCfgs))
+	for _, aCfg := range aCfgs {
+		st := subsysTarget{aCfg.SubSystem, aCfg.Target}
+		seen[st] = true
+		bCfg := bByTarget[st]
+		for _, kv := range aCfg.KV {
+			oldVal, _ := aCfg.Lookup(kv.Key)
+			newVal, ok := bCfg.Lookup(kv.Key)
+			change := ConfigChange{SubSystem: st.subSys, Target: st.target, Key: kv.Key, OldValue: oldVal, NewValue: newVal}
+			switch {
+			case !ok:
+				change.Type = ConfigChangeRemoved
+			case oldVal != newVal:
+				change.Type = ConfigChangeModified
+			default:
+				continue
+			}
+			changes = append(changes, change)
+		}
+		for _, kv := range bCfg.KV {
+			if _, ok := aCfg.Lookup(kv.Key); !ok {
+				newVal, _ := bCfg.Lookup(kv.Key)
+				changes = append(changes, ConfigChange{SubSystem: st.subSys, Target: st.target, Key: kv.Key, Type: ConfigChangeAdded, NewValue: newVal})
+			}
+		}
+	}
+	for _, bCfg := range bCfgs {
+		if seen[subsysTarget{bCfg.SubSystem, bCfg.Target}] {
+			continue
+		}
+		for _, kv := range bCfg.KV {
+			newVal, _ := bCfg.Lookup(kv.Key)
+			changes = append(changes, ConfigChange{SubSystem: bCfg.SubSystem, Target: bCfg.Target, Key: kv.Key, Type: ConfigChangeAdded, NewValue: newVal})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		ci, cj := changes[i], changes[j]
+		if ci.SubSystem != cj.SubSystem {
+			return ci.SubSystem < cj.SubSystem
+		}
+		if ci.Target != cj.Target {
+			return ci.Target < cj.Target
+		}
+		return ci.Key < cj.Key
+	})
+	return changes, nil
+}
@@ -198,3 +198,73 @@ logger_webhook:1 endpoint=http://localhost:8080/ auth_token= client_cert= client
 		}
 	}
 }
+
+func TestConfigDiff(t *testing.T) {
+	a := `site name=dc1 region=us-east-1
+# MINIO_SCANNER_SPEED=fast
+scanner speed=default idle_speed=
+notify_webhook:audit endpoint=http://a queue_limit=100
+subnet license= api_key=`
+	b := `site name=dc2 region=us-east-1
+scanner speed=fast idle_speed= cycle=1m
+subnet license= api_key=
+notify_webhook:orders endpoint=http://o`
+
+	changes, err := ConfigDiff([]byte(a), []byte(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ConfigChange{
+		{SubSystem: "notify_webhook", Target: "audit", Key: "endpoint", Type: ConfigChangeRemoved, OldValue: "http://a"},
+		{SubSystem: "notify_webhook", Target: "audit", Key: "queue_limit", Type: ConfigChangeRemoved, OldValue: "100"},
+		{SubSystem: "notify_webhook", Target: "orders", Key: "endpoint", Type: ConfigChangeAdded, NewValue: "http://o"},
+		{SubSystem: "scanner", Key: "cycle", Type: ConfigChangeAdded, NewValue: "1m"},
+		{SubSystem: "site", Key: "name", Type: ConfigChangeModified, OldValue: "dc1", NewValue: "dc2"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("expected %+v, got %+v", want, changes)
+	}
+
+	changes, err = ConfigDiff([]byte(a), []byte(a))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestSubsysConfigMerge(t *testing.T) {
+	var base SubsysConfig
+	base.SubSystem = NotifyWebhookSubSys
+	base.Target = "orders"
+	base.AddConfigKV(ConfigKV{Key: "endpoint", Value: "http://a"})
+	base.AddConfigKV(ConfigKV{Key: "queue_limit", Value: "100"})
+
+	override := SubsysConfig{SubSystem: NotifyWebhookSubSys, Target: "orders"}
+	override.AddConfigKV(ConfigKV{Key: "queue_limit", Value: "500"})
+	override.AddConfigKV(ConfigKV{Key: "auth_token", Value: "secret"})
+
+	merged := base.Merge(override)
+	want := []ConfigKV{
+		{Key: "endpoint", Value: "http://a"},
+		{Key: "queue_limit", Value: "500"},
+		{Key: "auth_token", Value: "secret"},
+	}
+	if !reflect.DeepEqual(merged.KV, want) {
+		t.Errorf("expected %v, got %v", want, merged.KV)
+	}
+	if v, ok := merged.Lookup("auth_token"); !ok || v != "secret" {
+		t.Errorf("expected auth_token to be looked up, got %q, %v", v, ok)
+	}
+	if v, _ := base.Lookup("queue_limit"); v != "100" {
+		t.Errorf("expected base to be unchanged, got queue_limit=%q", v)
+	}
+
+	// Overrides for another target are not applied.
+	override.Target = "audit"
+	merged = base.Merge(override)
+	if !reflect.DeepEqual(merged.KV, base.KV) {
+		t.Errorf("expected %v, got %v", base.KV, merged.KV)
+	}
+}
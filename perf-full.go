@@ -0,0 +1,124 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Speedtest phases reported in SpeedtestNodeError.
+const (
+	SpeedtestPhaseObject = "object"
+	SpeedtestPhaseDrive  = "drive"
+	SpeedtestPhaseNet    = "net"
+)
+
+// FullSpeedtestOpts provide configurable options for FullSpeedtest
+type FullSpeedtestOpts struct {
+	Object      SpeedtestOpts      // Options of the object speed test
+	Drive       DriveSpeedTestOpts // Options of the drive speed test
+	NetDuration time.Duration      // Duration of the network speed test
+}
+
+// SpeedtestNodeError - error reported by a single node during a speed test
+type SpeedtestNodeError struct {
+	Phase    string `json:"phase"`
+	Endpoint string `json:"endpoint"`
+	Drive    string `json:"drive,omitempty"` // Path of the failed drive, drive phase only
+	Error    string `json:"error"`
+}
+
+// FullSpeedtestResult - results of all the phases of FullSpeedtest,
+// a phase that was not run has no result.
+type FullSpeedtestResult struct {
+	Object     *SpeedTestResult       `json:"object,omitempty"`
+	Drive      []DriveSpeedTestResult `json:"drive,omitempty"`
+	Net        *NetperfResult         `json:"net,omitempty"`
+	NodeErrors []SpeedtestNodeError   `json:"nodeErrors,omitempty"`
+}
+
+// FullSpeedtest - runs the object, drive and network speed tests one
+// after the other. Errors reported by individual nodes are collected in
+// NodeErrors, while any other error aborts the remaining phases and is
+// returned along with the results of the phases that completed.
+func (adm *AdminClient) FullSpeedtest(ctx context.Context, opts FullSpeedtestOpts) (result FullSpeedtestResult, err error) {
+	objCh, err := adm.Speedtest(ctx, opts.Object)
+	if err != nil {
+		return result, err
+	}
+	for r := range objCh {
+		result.Object = &r
+	}
+	if err = ctx.Err(); err != nil {
+		return result, err
+	}
+	if result.Object == nil {
+		return result, errors.New("object speedtest returned no result")
+	}
+	for _, stats := range []SpeedTestStats{result.Object.PUTStats, result.Object.GETStats} {
+		for _, s := range stats.Servers {
+			if s.Err != "" {
+				result.addNodeError(SpeedtestPhaseObject, s.Endpoint, s.Err)
+			}
+		}
+	}
+
+	driveCh, err := adm.DriveSpeedtest(ctx, opts.Drive)
+	if err != nil {
+		return result, err
+	}
+	for r := range driveCh {
+		result.Drive = append(result.Drive, r)
+		if r.Error != "" {
+			result.addNodeError(SpeedtestPhaseDrive, r.Endpoint, r.Error)
+		}
+		for _, d := range r.DrivePerf {
+			if d.Error != "" {
+				result.NodeErrors = append(result.NodeErrors, SpeedtestNodeError{
+					Phase:    SpeedtestPhaseDrive,
+					Endpoint: r.Endpoint,
+					Drive:    d.Path,
+					Error:    d.Error,
+				})
+			}
+		}
+	}
+	if err = ctx.Err(); err != nil {
+		return result, err
+	}
+
+	netResult, err := adm.Netperf(ctx, opts.NetDuration)
+	if err != nil {
+		return result, err
+	}
+	result.Net = &netResult
+	for _, n := range netResult.NodeResults {
+		if n.Error != "" {
+			result.addNodeError(SpeedtestPhaseNet, n.Endpoint, n.Error)
+		}
+	}
+	return result, nil
+}
+
+func (r *FullSpeedtestResult) addNodeError(phase, endpoint, err string) {
+	r.NodeErrors = append(r.NodeErrors, SpeedtestNodeError{Phase: phase, Endpoint: endpoint, Error: err})
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestFullSpeedtest(t *testing.T) {
+	var calls []string
+	driveStatus := http.StatusOK
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		switch r.URL.Path {
+		case "/minio/admin/v3/speedtest":
+			w.Write([]byte(`{"version":"1","servers":2,"PUTStats":{"throughputPerSec":100},"GETStats":{"throughputPerSec":200}}
+{"version":"1","servers":2,"PUTStats":{"throughputPerSec":300,"servers":[{"endpoint":"node1:9000","throughputPerSec":300},{"endpoint":"node2:9000","err":"disk full"}]},"GETStats":{"throughputPerSec":400}}
+`))
+		case "/minio/admin/v3/speedtest/drive":
+			if driveStatus != http.StatusOK {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(driveStatus)
+				w.Write([]byte(`{"Code":"XMinioAdminSpeedtestBusy","Message":"another speedtest is running"}`))
+				return
+			}
+			w.Write([]byte(`{"endpoint":"node1:9000","drivePerf":[{"path":"/disk1","readThroughput":10,"writeThroughput":20},{"path":"/disk2","error":"faulty disk"}]}
+{"endpoint":"node2:9000","drivePerf":[{"path":"/disk1","readThroughput":30,"writeThroughput":40}]}
+`))
+		case "/minio/admin/v3/speedtest/net":
+			w.Write([]byte(`{"nodeResults":[{"endpoint":"node1:9000","tx":100,"rx":100},{"endpoint":"node2:9000","error":"connection refused"}]}`))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	})
+	opts := FullSpeedtestOpts{Object: SpeedtestOpts{Autotune: true}}
+
+	result, err := adm.FullSpeedtest(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCalls := []string{"/minio/admin/v3/speedtest", "/minio/admin/v3/speedtest/drive", "/minio/admin/v3/speedtest/net"}
+	if !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("expected calls %q, got %q", wantCalls, calls)
+	}
+	if result.Object == nil || result.Object.PUTStats.ThroughputPerSec != 300 {
+		t.Errorf("expected the final object speedtest result, got %+v", result.Object)
+	}
+	if len(result.Drive) != 2 || result.Net == nil || len(result.Net.NodeResults) != 2 {
+		t.Errorf("unexpected drive %+v or net %+v results", result.Drive, result.Net)
+	}
+	wantErrs := []SpeedtestNodeError{
+		{Phase: SpeedtestPhaseObject, Endpoint: "node2:9000", Error: "disk full"},
+		{Phase: SpeedtestPhaseDrive, Endpoint: "node1:9000", Drive: "/disk2", Error: "faulty disk"},
+		{Phase: SpeedtestPhaseNet, Endpoint: "node2:9000", Error: "connection refused"},
+	}
+	if !reflect.DeepEqual(result.NodeErrors, wantErrs) {
+		t.Errorf("expected node errors %+v, got %+v", wantErrs, result.NodeErrors)
+	}
+
+	calls = nil
+	driveStatus = http.StatusConflict
+	result, err = adm.FullSpeedtest(context.Background(), opts)
+	if ToErrorResponse(err).Code != "XMinioAdminSpeedtestBusy" {
+		t.Fatalf("expected drive speedtest error, got %v", err)
+	}
+	if !reflect.DeepEqual(calls, wantCalls[:2]) {
+		t.Errorf("expected calls %q, got %q", wantCalls[:2], calls)
+	}
+	if result.Object == nil || result.Drive != nil || result.Net != nil {
+		t.Errorf("expected only the object result, got %+v", result)
+	}
+}
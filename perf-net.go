@@ -40,6 +40,45 @@ type NetperfResult struct {
 	NodeResults []NetperfNodeResult `json:"nodeResults"`
 }
 
+// NetperfSummary - cluster wide throughput aggregates of a netperf run
+type NetperfSummary struct {
+	Nodes  int    `json:"nodes"`
+	Errors int    `json:"errors"`
+	MinTX  uint64 `json:"minTX"`
+	MaxTX  uint64 `json:"maxTX"`
+	AvgTX  uint64 `json:"avgTX"`
+	MinRX  uint64 `json:"minRX"`
+	MaxRX  uint64 `json:"maxRX"`
+	AvgRX  uint64 `json:"avgRX"`
+}
+
+// Summary - returns the min, max and average throughput across all nodes,
+// nodes that reported an error are counted but otherwise skipped.
+func (r NetperfResult) Summary() (s NetperfSummary) {
+	var totalTX, totalRX uint64
+	for _, n := range r.NodeResults {
+		if n.Error != "" {
+			s.Errors++
+			continue
+		}
+		if s.Nodes == 0 {
+			s.MinTX, s.MinRX = n.TX, n.RX
+		}
+		s.Nodes++
+		s.MinTX = min(s.MinTX, n.TX)
+		s.MaxTX = max(s.MaxTX, n.TX)
+		s.MinRX = min(s.MinRX, n.RX)
+		s.MaxRX = max(s.MaxRX, n.RX)
+		totalTX += n.TX
+		totalRX += n.RX
+	}
+	if s.Nodes > 0 {
+		s.AvgTX = totalTX / uint64(s.Nodes)
+		s.AvgRX = totalRX / uint64(s.Nodes)
+	}
+	return s
+}
+
 // Netperf - perform netperf on the MinIO servers
 func (adm *AdminClient) Netperf(ctx context.Context, duration time.Duration) (result NetperfResult, err error) {
 	queryVals := make(url.Values)
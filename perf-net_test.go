@@ -0,0 +1,62 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import "testing"
+
+func TestNetperfResultSummary(t *testing.T) {
+	testCases := []struct {
+		name   string
+		result NetperfResult
+		want   NetperfSummary
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "all errored",
+			result: NetperfResult{NodeResults: []NetperfNodeResult{
+				{Endpoint: "node1:9000", Error: "connection refused"},
+			}},
+			want: NetperfSummary{Errors: 1},
+		},
+		{
+			name: "skips errored node",
+			result: NetperfResult{NodeResults: []NetperfNodeResult{
+				{Endpoint: "node1:9000", TX: 100, RX: 300},
+				{Endpoint: "node2:9000", TX: 1 << 40, RX: 1 << 40, Error: "timeout"},
+				{Endpoint: "node3:9000", TX: 300, RX: 200},
+				{Endpoint: "node4:9000", TX: 200, RX: 100},
+			}},
+			want: NetperfSummary{
+				Nodes: 3, Errors: 1,
+				MinTX: 100, MaxTX: 300, AvgTX: 200,
+				MinRX: 100, MaxRX: 300, AvgRX: 200,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.result.Summary(); got != tc.want {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -25,10 +25,12 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"time"
 )
 
 // InfoCannedPolicy - expand canned policy into JSON structure.
+// If the policy does not exist, the returned error matches ErrPolicyNotFound.
 //
 // Deprecated: Use InfoCannedPolicyV2 instead.
 func (adm *AdminClient) InfoCannedPolicy(ctx context.Context, policyName string) ([]byte, error) {
@@ -78,7 +80,13 @@ func (pi PolicyInfo) MarshalJSON() ([]byte, error) {
 	return json.Marshal(aliasPolicyInfo(pi))
 }
 
+// Statements parses the statements of the policy document.
+func (pi PolicyInfo) Statements() ([]PolicyStatement, error) {
+	return ParsePolicyStatements(pi.Policy)
+}
+
 // InfoCannedPolicyV2 - get info on a policy including timestamps and policy json.
+// If the policy does not exist, the returned error matches ErrPolicyNotFound.
 func (adm *AdminClient) InfoCannedPolicyV2(ctx context.Context, policyName string) (*PolicyInfo, error) {
 	queryValues := url.Values{}
 	queryValues.Set("name", policyName)
@@ -143,6 +151,7 @@ func (adm *AdminClient) ListCannedPolicies(ctx context.Context) (map[string]json
 }
 
 // RemoveCannedPolicy - remove a policy for a canned.
+// If the policy does not exist, the returned error matches ErrPolicyNotFound.
 func (adm *AdminClient) RemoveCannedPolicy(ctx context.Context, policyName string) error {
 	queryValues := url.Values{}
 	queryValues.Set("name", policyName)
@@ -197,6 +206,36 @@ func (adm *AdminClient) AddCannedPolicy(ctx context.Context, policyName string,
 	return nil
 }
 
+// addCannedPoliciesWorkers is the number of policies AddCannedPolicies
+// uploads concurrently.
+const addCannedPoliciesWorkers = 8
+
+// AddCannedPolicies - adds several canned policies, uploading up to
+// eight of them concurrently. The returned map holds the result of
+// every policy by name, nil on success. The error is only set if
+// the context was cancelled before all policies were processed.
+func (adm *AdminClient) AddCannedPolicies(ctx context.Context, policies map[string][]byte) (map[string]error, error) {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+
+	errs := make([]error, len(names))
+	runConcurrently(len(names), addCannedPoliciesWorkers, func(i int) {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			return
+		}
+		errs[i] = adm.AddCannedPolicy(ctx, names[i], policies[names[i]])
+	})
+
+	results := make(map[string]error, len(names))
+	for i, name := range names {
+		results[name] = errs[i]
+	}
+	return results, ctx.Err()
+}
+
 // SetPolicy - sets the policy for a user or a group.
 //
 // Deprecated: Use AttachPolicy/DetachPolicy to update builtin user policies
@@ -332,3 +371,38 @@ func (adm *AdminClient) GetPolicyEntities(ctx context.Context, q PolicyEntitiesQ
 	err = json.Unmarshal(content, &r)
 	return r, err
 }
+
+// GroupsWithPolicy - returns the names of the groups the given policy is
+// attached to, sorted.
+func (adm *AdminClient) GroupsWithPolicy(ctx context.Context, policy string) ([]string, error) {
+	r, err := adm.GetPolicyEntities(ctx, PolicyEntitiesQuery{Policy: []string{policy}})
+	if err != nil {
+		return nil, err
+	}
+	var groups []string
+	for _, m := range r.PolicyMappings {
+		if m.Policy == policy {
+			groups = append(groups, m.Groups...)
+		}
+	}
+	sort.Strings(groups)
+	return groups, nil
+}
+
+// UsersWithPolicy - returns the access keys of the users the given policy
+// is attached to directly, sorted. Users that only get the policy through
+// one of their groups are not included.
+func (adm *AdminClient) UsersWithPolicy(ctx context.Context, policy string) ([]string, error) {
+	r, err := adm.GetPolicyEntities(ctx, PolicyEntitiesQuery{Policy: []string{policy}})
+	if err != nil {
+		return nil, err
+	}
+	var users []string
+	for _, m := range r.PolicyMappings {
+		if m.Policy == policy {
+			users = append(users, m.Users...)
+		}
+	}
+	sort.Strings(users)
+	return users, nil
+}
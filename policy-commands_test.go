@@ -21,7 +21,14 @@ package madmin
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 )
@@ -67,3 +74,148 @@ func TestPolicyInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicyNotFound(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"Code":"XMinioAdminNoSuchPolicy","Message":"The canned policy does not exist."}`))
+	})
+
+	ctx := context.Background()
+	err := adm.RemoveCannedPolicy(ctx, "missing")
+	if !errors.Is(err, ErrPolicyNotFound) {
+		t.Errorf("RemoveCannedPolicy: expected ErrPolicyNotFound, got %v", err)
+	}
+	if code := ToErrorResponse(err).Code; code != "XMinioAdminNoSuchPolicy" {
+		t.Errorf("RemoveCannedPolicy: expected error code XMinioAdminNoSuchPolicy, got %q", code)
+	}
+	if _, err = adm.InfoCannedPolicyV2(ctx, "missing"); !errors.Is(err, ErrPolicyNotFound) {
+		t.Errorf("InfoCannedPolicyV2: expected ErrPolicyNotFound, got %v", err)
+	}
+	if _, err = adm.InfoCannedPolicy(ctx, "missing"); !errors.Is(err, ErrPolicyNotFound) {
+		t.Errorf("InfoCannedPolicy: expected ErrPolicyNotFound, got %v", err)
+	}
+}
+
+func TestErrorResponseIs(t *testing.T) {
+	if errors.Is(ErrorResponse{Code: "AccessDenied"}, ErrPolicyNotFound) {
+		t.Error("unexpected match for AccessDenied")
+	}
+	if !errors.Is(ErrorResponse{Code: "XMinioAdminNoSuchPolicy"}, ErrPolicyNotFound) {
+		t.Error("expected match for XMinioAdminNoSuchPolicy")
+	}
+}
+
+func TestAddCannedPolicies(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		added = make(map[string]string)
+	)
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		if name == "broken" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"Code":"XMinioMalformedIAMPolicy","Message":"policy has invalid resource"}`))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		added[name] = string(body)
+		mu.Unlock()
+	})
+
+	policies := map[string][]byte{
+		"broken": []byte(`{"Version":"2012-10-17"}`),
+	}
+	for i := 0; i < 20; i++ {
+		policies[fmt.Sprintf("policy-%d", i)] = []byte(fmt.Sprintf(`{"Version":"2012-10-17","Id":"%d"}`, i))
+	}
+
+	results, err := adm.AddCannedPolicies(context.Background(), policies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(policies) {
+		t.Fatalf("expected %d results, got %d", len(policies), len(results))
+	}
+	for name, err := range results {
+		if name == "broken" {
+			if code := ToErrorResponse(err).Code; code != "XMinioMalformedIAMPolicy" {
+				t.Errorf("%s: expected XMinioMalformedIAMPolicy, got %v", name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if added[name] != string(policies[name]) {
+			t.Errorf("%s: expected policy %s, got %s", name, policies[name], added[name])
+		}
+	}
+	if _, ok := added["broken"]; ok {
+		t.Error("broken policy was added")
+	}
+}
+
+// newPolicyEntitiesClient returns a client for a test server answering
+// builtin policy entities queries for policy with r.
+func newPolicyEntitiesClient(t *testing.T, policy string, r PolicyEntitiesResult) *AdminClient {
+	return newTestAdminClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/minio/admin/v3/idp/builtin/policy-entities" {
+			t.Errorf("unexpected path %q", req.URL.Path)
+		}
+		if got := req.URL.Query()["policy"]; !reflect.DeepEqual(got, []string{policy}) {
+			t.Errorf("unexpected policy query %q", got)
+		}
+		data, err := json.Marshal(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		edata, err := EncryptData("minioadmin", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(edata)
+	})
+}
+
+func TestGroupsWithPolicy(t *testing.T) {
+	adm := newPolicyEntitiesClient(t, "readwrite", PolicyEntitiesResult{
+		PolicyMappings: []PolicyEntities{{
+			Policy: "readwrite",
+			Users:  []string{"alice"},
+			Groups: []string{"ops", "devs"},
+		}},
+	})
+
+	groups, err := adm.GroupsWithPolicy(context.Background(), "readwrite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"devs", "ops"}; !reflect.DeepEqual(groups, want) {
+		t.Errorf("expected groups %q, got %q", want, groups)
+	}
+}
+
+func TestUsersWithPolicy(t *testing.T) {
+	adm := newPolicyEntitiesClient(t, "diagnostics", PolicyEntitiesResult{
+		PolicyMappings: []PolicyEntities{{
+			Policy: "diagnostics",
+			Users:  []string{"alice"},
+			Groups: []string{"ops"},
+		}},
+	})
+
+	users, err := adm.UsersWithPolicy(context.Background(), "diagnostics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"alice"}; !reflect.DeepEqual(users, want) {
+		t.Errorf("expected users %q, got %q", want, users)
+	}
+}
@@ -20,6 +20,8 @@
 package madmin
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -27,6 +29,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -47,6 +50,18 @@ const (
 	ProfilerRuntime    ProfilerType = "runtime"    // Include runtime metrics
 )
 
+// ProfilerTypes is a set of profiler types to run at the same time.
+type ProfilerTypes []ProfilerType
+
+// String returns the profiler types as a comma separated list.
+func (p ProfilerTypes) String() string {
+	types := make([]string, 0, len(p))
+	for _, t := range p {
+		types = append(types, string(t))
+	}
+	return strings.Join(types, ",")
+}
+
 // StartProfilingResult holds the result of starting
 // profiler result in a given node.
 type StartProfilingResult struct {
@@ -118,6 +133,86 @@ func (adm *AdminClient) DownloadProfilingData(ctx context.Context) (io.ReadClose
 	return resp.Body, nil
 }
 
+// ProfilingProfiles downloads the profiling data started with StartProfiling
+// and returns the profiles it contains keyed by file name, for example
+// "profile-node1:9000-cpu.pprof". The profiles can be passed to pprof as is.
+func (adm *AdminClient) ProfilingProfiles(ctx context.Context) (map[string][]byte, error) {
+	data, err := adm.DownloadProfilingData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Close()
+	return unzipProfiles(data)
+}
+
+// ProfileFor starts the given profilers, waits for d and then stops them,
+// returning the profiles of all nodes keyed by file name. Once the start
+// request has been sent the profilers are always stopped, even if starting
+// them failed or ctx is canceled while waiting; the error is then that of
+// the start request or ctx.
+func (adm *AdminClient) ProfileFor(ctx context.Context, types ProfilerTypes, d time.Duration) (map[string][]byte, error) {
+	if _, err := adm.StartProfiling(ctx, ProfilerType(types.String())); err != nil {
+		// The server may have started profiling before the request failed.
+		adm.stopProfiling(ctx)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return adm.ProfilingProfiles(ctx)
+	case <-ctx.Done():
+		adm.stopProfiling(ctx)
+		return nil, ctx.Err()
+	}
+}
+
+// stopProfiling stops the running profilers, ignoring cancellation of ctx.
+// Downloading the profiling data stops the profilers.
+func (adm *AdminClient) stopProfiling(ctx context.Context) {
+	data, err := adm.DownloadProfilingData(context.WithoutCancel(ctx))
+	if err == nil {
+		data.Close()
+	}
+}
+
+// unzipProfiles returns the files of the zip archive read from r.
+// An empty response is treated as an archive without files.
+func unzipProfiles(r io.Reader) (map[string][]byte, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make(map[string][]byte)
+	if len(buf) == 0 {
+		return profiles, nil
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		profiles[f.Name], err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return profiles, nil
+}
+
 // Profile makes an admin call to remotely start profiling on a standalone
 // server or the whole cluster in  case of a distributed setup for a specified duration.
 func (adm *AdminClient) Profile(ctx context.Context, profiler ProfilerType, duration time.Duration) (io.ReadCloser, error) {
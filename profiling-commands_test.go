@@ -0,0 +1,164 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// zipProfiles returns a zip archive containing files.
+func zipProfiles(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestProfilingProfiles(t *testing.T) {
+	want := map[string][]byte{
+		"profile-node1:9000-cpu.pprof": []byte("cpu profile of node1"),
+		"profile-node2:9000-cpu.pprof": []byte("cpu profile of node2"),
+	}
+	var body []byte
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/profiling/download" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Write(body)
+	})
+
+	ctx := context.Background()
+	body = zipProfiles(t, want)
+	profiles, err := adm.ProfilingProfiles(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(profiles, want) {
+		t.Errorf("expected profiles %q, got %q", want, profiles)
+	}
+
+	for _, empty := range [][]byte{zipProfiles(t, nil), nil} {
+		body = empty
+		profiles, err = adm.ProfilingProfiles(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(profiles) != 0 {
+			t.Errorf("expected no profiles, got %q", profiles)
+		}
+	}
+}
+
+func TestProfileFor(t *testing.T) {
+	want := map[string][]byte{"profile-node1:9000-cpu.pprof": []byte("cpu"), "profile-node1:9000-mem.pprof": []byte("mem")}
+	var (
+		mu     sync.Mutex
+		calls  []string
+		cancel context.CancelFunc
+	)
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.URL.Path)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/minio/admin/v3/profiling/start":
+			if got := r.URL.Query().Get("profilerType"); got != "cpu,mem" {
+				t.Errorf("unexpected profiler types %q", got)
+			}
+			if cancel != nil {
+				cancel()
+			}
+			w.Write([]byte(`[{"nodeName":"node1:9000","success":true}]`))
+		case "/minio/admin/v3/profiling/download":
+			w.Write(zipProfiles(t, want))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	})
+	types := ProfilerTypes{ProfilerCPU, ProfilerMEM}
+	wantCalls := []string{"/minio/admin/v3/profiling/start", "/minio/admin/v3/profiling/download"}
+
+	profiles, err := adm.ProfileFor(context.Background(), types, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(profiles, want) {
+		t.Errorf("expected profiles %q, got %q", want, profiles)
+	}
+	if !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("expected calls %q, got %q", wantCalls, calls)
+	}
+
+	calls = nil
+	var ctx context.Context
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	_, err = adm.ProfileFor(ctx, types, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("expected profiling to be stopped after cancel, got calls %q", calls)
+	}
+}
+
+func TestProfileForStartError(t *testing.T) {
+	var calls []string
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		if r.URL.Path == "/minio/admin/v3/profiling/start" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"Code":"XMinioAdminProfilerNotEnabled","Message":"profiler not enabled"}`))
+			return
+		}
+		w.Write(nil)
+	})
+
+	_, err := adm.ProfileFor(context.Background(), ProfilerTypes{ProfilerCPU}, time.Hour)
+	var errResp ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Code != "XMinioAdminProfilerNotEnabled" {
+		t.Fatalf("expected the start error, got %v", err)
+	}
+	want := []string{"/minio/admin/v3/profiling/start", "/minio/admin/v3/profiling/download"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected profiling to be stopped after a failed start, got calls %q", calls)
+	}
+}
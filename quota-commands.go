@@ -20,6 +20,7 @@
 package madmin
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
@@ -47,6 +48,29 @@ type BucketQuota struct {
 	Rate     uint64    `json:"rate"`     // Indicates bandwidth rate allocated per bucket
 	Requests uint64    `json:"requests"` // Indicates number of requests allocated per bucket
 	Type     QuotaType `json:"quotatype,omitempty"`
+
+	set bool // whether the quota was returned by the server
+}
+
+// IsSet returns true if the quota was returned by GetBucketQuota, telling
+// a quota explicitly set to 0 apart from a quota that was never set.
+func (q BucketQuota) IsSet() bool {
+	return q.set
+}
+
+// UnmarshalJSON decodes a quota, recording whether one was present.
+func (q *BucketQuota) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	type bucketQuota BucketQuota
+	var v bucketQuota
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*q = BucketQuota(v)
+	q.set = true
+	return nil
 }
 
 // IsValid returns false if quota is invalid
@@ -59,7 +83,8 @@ func (q BucketQuota) IsValid() bool {
 	return true
 }
 
-// GetBucketQuota - get info on a user
+// GetBucketQuota - get info on a user, the quota is not set
+// if the server returned none.
 func (adm *AdminClient) GetBucketQuota(ctx context.Context, bucket string) (q BucketQuota, err error) {
 	queryValues := url.Values{}
 	queryValues.Set("bucket", bucket)
@@ -85,6 +110,9 @@ func (adm *AdminClient) GetBucketQuota(ctx context.Context, bucket string) (q Bu
 	if err != nil {
 		return q, err
 	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return q, nil
+	}
 	if err = json.Unmarshal(b, &q); err != nil {
 		return q, err
 	}
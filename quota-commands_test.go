@@ -0,0 +1,61 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetBucketQuotaIsSet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		response string
+		wantSet  bool
+		wantSize uint64
+	}{
+		{name: "zero quota", response: `{"quota":0,"size":0,"rate":0,"requests":0,"quotatype":"hard"}`, wantSet: true},
+		{name: "quota", response: `{"size":1073741824,"quotatype":"hard"}`, wantSet: true, wantSize: 1 << 30},
+		{name: "null", response: `null`},
+		{name: "empty", response: ``},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/minio/admin/v3/get-bucket-quota" || r.URL.Query().Get("bucket") != "bucket" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				w.Write([]byte(tc.response))
+			})
+			q, err := adm.GetBucketQuota(context.Background(), "bucket")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if q.IsSet() != tc.wantSet || q.Size != tc.wantSize {
+				t.Errorf("expected set %v with size %d, got %+v", tc.wantSet, tc.wantSize, q)
+			}
+		})
+	}
+
+	if (BucketQuota{}).IsSet() {
+		t.Error("expected a zero BucketQuota to not be set")
+	}
+}
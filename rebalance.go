@@ -53,6 +53,32 @@ type RebalanceStatus struct {
 	Pools     []RebalancePoolStatus `json:"pools"` // contains all pools, including inactive
 }
 
+// rebalanceStartedStatus is the status of a pool being rebalanced.
+const rebalanceStartedStatus = "Started"
+
+// PercentDone returns how much of the rebalance of the pool is done in percent,
+// estimated from the time elapsed and the time remaining.
+func (p RebalancePoolStatus) PercentDone() float64 {
+	total := p.Progress.Elapsed + p.Progress.ETA
+	if total <= 0 {
+		return 0
+	}
+	return 100 * float64(p.Progress.Elapsed) / float64(total)
+}
+
+// done returns true if the rebalance was stopped or no pool is being rebalanced.
+func (r RebalanceStatus) done() bool {
+	if !r.StoppedAt.IsZero() {
+		return true
+	}
+	for _, p := range r.Pools {
+		if p.Status == rebalanceStartedStatus {
+			return false
+		}
+	}
+	return true
+}
+
 // RebalanceStart starts a rebalance operation if one isn't in progress already
 func (adm *AdminClient) RebalanceStart(ctx context.Context) (id string, err error) {
 	// Execute POST on /minio/admin/v3/rebalance/start to start a rebalance operation.
@@ -112,6 +138,43 @@ func (adm *AdminClient) RebalanceStatus(ctx context.Context) (r RebalanceStatus,
 	return r, nil
 }
 
+// RebalanceWatch - polls the status of the ongoing rebalance every interval,
+// sending each status until the rebalance of all pools is done. Both channels
+// are closed once the rebalance is done, on error or when ctx is canceled.
+func (adm *AdminClient) RebalanceWatch(ctx context.Context, interval time.Duration) (<-chan RebalanceStatus, <-chan error) {
+	statusCh := make(chan RebalanceStatus)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(errCh)
+		defer close(statusCh)
+
+		for {
+			status, err := adm.RebalanceStatus(ctx)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case statusCh <- status:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+			if status.done() {
+				return
+			}
+
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return statusCh, errCh
+}
+
 func (adm *AdminClient) RebalanceStop(ctx context.Context) error {
 	// Execute POST on /minio/admin/v3/rebalance/stop to stop an ongoing rebalance operation.
 	resp, err := adm.executeMethod(ctx,
@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRebalanceWatch(t *testing.T) {
+	statuses := []RebalanceStatus{
+		{ID: "rebalance", Pools: []RebalancePoolStatus{
+			{ID: 0, Status: "Started", Progress: RebalPoolProgress{Elapsed: time.Minute, ETA: 3 * time.Minute}},
+			{ID: 1, Status: "Started"},
+		}},
+		{ID: "rebalance", Pools: []RebalancePoolStatus{
+			{ID: 0, Status: "Completed", Progress: RebalPoolProgress{Elapsed: 4 * time.Minute}},
+			{ID: 1, Status: "Started", Progress: RebalPoolProgress{Elapsed: 4 * time.Minute, ETA: 4 * time.Minute}},
+		}},
+		{ID: "rebalance", Pools: []RebalancePoolStatus{
+			{ID: 0, Status: "Completed", Progress: RebalPoolProgress{Elapsed: 4 * time.Minute}},
+			{ID: 1, Status: "Completed", Progress: RebalPoolProgress{Elapsed: 8 * time.Minute}},
+		}},
+	}
+	polls := 0
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/rebalance/status" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if polls >= len(statuses) {
+			t.Error("polled after the rebalance completed")
+			return
+		}
+		json.NewEncoder(w).Encode(statuses[polls])
+		polls++
+	})
+
+	statusCh, errCh := adm.RebalanceWatch(context.Background(), time.Millisecond)
+	var got []RebalanceStatus
+	for status := range statusCh {
+		got = append(got, status)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(statuses) {
+		t.Fatalf("expected %d statuses, got %d: %+v", len(statuses), len(got), got)
+	}
+
+	wantPercent := [][]float64{{25, 0}, {100, 50}, {100, 100}}
+	for i, status := range got {
+		for j, p := range status.Pools {
+			if pct := p.PercentDone(); pct != wantPercent[i][j] {
+				t.Errorf("status %d: expected pool %d to be %v%% done, got %v%%", i, j, wantPercent[i][j], pct)
+			}
+		}
+	}
+}
@@ -163,6 +163,38 @@ func (t *BucketTarget) String() string {
 	return fmt.Sprintf("%s %s", t.Endpoint, t.TargetBucket)
 }
 
+// InvalidBucketTargetError is returned by BucketTarget.Validate
+// listing the required fields missing from a target.
+type InvalidBucketTargetError struct {
+	Missing []string
+}
+
+func (e InvalidBucketTargetError) Error() string {
+	return "invalid remote target, missing " + strings.Join(e.Missing, ", ")
+}
+
+// Validate returns an InvalidBucketTargetError if any of the fields
+// required to set a remote target are missing.
+func (t BucketTarget) Validate() error {
+	var missing []string
+	if t.Endpoint == "" {
+		missing = append(missing, "endpoint")
+	}
+	if t.Credentials == nil || t.Credentials.AccessKey == "" || t.Credentials.SecretKey == "" {
+		missing = append(missing, "credentials")
+	}
+	if t.TargetBucket == "" {
+		missing = append(missing, "target bucket")
+	}
+	if t.Type == "" {
+		missing = append(missing, "type")
+	}
+	if len(missing) > 0 {
+		return InvalidBucketTargetError{Missing: missing}
+	}
+	return nil
+}
+
 // BucketTargets represents a slice of bucket targets by type and endpoint
 type BucketTargets struct {
 	Targets []BucketTarget
@@ -215,8 +247,70 @@ func (adm *AdminClient) ListRemoteTargets(ctx context.Context, bucket, arnType s
 	return targets, nil
 }
 
+// ListRemoteTargetsByType - gets the targets of bucket with any of the given
+// ARN types, or all targets of bucket if no type or only empty types are given.
+func (adm *AdminClient) ListRemoteTargetsByType(ctx context.Context, bucket string, arnType ...string) ([]BucketTarget, error) {
+	targets, err := adm.ListRemoteTargets(ctx, bucket, "")
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[ServiceType]struct{}, len(arnType))
+	for _, t := range arnType {
+		if t != "" {
+			types[ServiceType(t)] = struct{}{}
+		}
+	}
+	if len(types) == 0 {
+		return targets, nil
+	}
+	filtered := targets[:0]
+	for _, t := range targets {
+		if _, ok := types[t.Type]; ok {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered, nil
+}
+
+// TargetHealth represents the health of a replication target as last
+// seen by the server.
+type TargetHealth struct {
+	Endpoint      string        `json:"endpoint"`
+	Online        bool          `json:"isOnline"`
+	LastOnline    time.Time     `json:"lastOnline"`
+	TotalDowntime time.Duration `json:"totalDowntime"`
+	Latency       LatencyStat   `json:"latency"`
+}
+
+// ReplicationTargetHealth - returns the health of the replication targets
+// of bucket keyed by ARN. The server does not report the last error or the
+// number of failed health checks of a target, only whether it is online.
+func (adm *AdminClient) ReplicationTargetHealth(ctx context.Context, bucket string) (map[string]TargetHealth, error) {
+	targets, err := adm.ListRemoteTargets(ctx, bucket, string(ReplicationService))
+	if err != nil {
+		return nil, err
+	}
+	health := make(map[string]TargetHealth, len(targets))
+	for _, t := range targets {
+		health[t.Arn] = TargetHealth{
+			Endpoint:      t.Endpoint,
+			Online:        t.Online,
+			LastOnline:    t.LastOnline,
+			TotalDowntime: t.TotalDowntime,
+			Latency:       t.Latency,
+		}
+	}
+	return health, nil
+}
+
 // SetRemoteTarget sets up a remote target for this bucket
 func (adm *AdminClient) SetRemoteTarget(ctx context.Context, bucket string, target *BucketTarget) (string, error) {
+	if target == nil {
+		return "", fmt.Errorf("target cannot be nil")
+	}
+	if err := target.Validate(); err != nil {
+		return "", err
+	}
 	data, err := json.Marshal(target)
 	if err != nil {
 		return "", err
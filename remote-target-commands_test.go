@@ -20,8 +20,13 @@
 package madmin
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"net/url"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func isOpsEqual(op1 []TargetUpdateType, op2 []TargetUpdateType) bool {
@@ -100,3 +105,149 @@ func TestGetTargetUpdateOps(t *testing.T) {
 		}
 	}
 }
+
+func TestReplicationTargetHealth(t *testing.T) {
+	const (
+		onlineARN  = "arn:minio:replication::2a2e1ea8:target-1"
+		offlineARN = "arn:minio:replication::94ac5e83:target-2"
+	)
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/list-remote-targets" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("bucket") != "bucket" || q.Get("type") != "replication" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		w.Write([]byte(`[
+	{"sourcebucket":"bucket","endpoint":"site1:9000","targetbucket":"target-1","arn":"` + onlineARN + `","type":"replication","isOnline":true,"lastOnline":"2024-05-01T10:00:00Z","latency":{"curr":1000000,"avg":2000000,"max":5000000}},
+	{"sourcebucket":"bucket","endpoint":"site2:9000","targetbucket":"target-2","arn":"` + offlineARN + `","type":"replication","isOnline":false,"lastOnline":"2024-05-01T09:00:00Z","totalDowntime":3600000000000}
+]`))
+	})
+
+	health, err := adm.ReplicationTargetHealth(context.Background(), "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]TargetHealth{
+		onlineARN: {
+			Endpoint:   "site1:9000",
+			Online:     true,
+			LastOnline: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			Latency:    LatencyStat{Curr: time.Millisecond, Avg: 2 * time.Millisecond, Max: 5 * time.Millisecond},
+		},
+		offlineARN: {
+			Endpoint:      "site2:9000",
+			LastOnline:    time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+			TotalDowntime: time.Hour,
+		},
+	}
+	if !reflect.DeepEqual(health, want) {
+		t.Errorf("expected %+v, got %+v", want, health)
+	}
+}
+
+func TestBucketTargetValidate(t *testing.T) {
+	valid := BucketTarget{
+		SourceBucket: "bucket",
+		Endpoint:     "site2:9000",
+		Credentials:  &Credentials{AccessKey: "minioadmin", SecretKey: "minioadmin"},
+		TargetBucket: "target",
+		Type:         ReplicationService,
+	}
+	testCases := []struct {
+		name    string
+		modify  func(t *BucketTarget)
+		missing []string
+	}{
+		{name: "valid", modify: func(*BucketTarget) {}},
+		{name: "no endpoint", modify: func(t *BucketTarget) { t.Endpoint = "" }, missing: []string{"endpoint"}},
+		{name: "no credentials", modify: func(t *BucketTarget) { t.Credentials = nil }, missing: []string{"credentials"}},
+		{name: "no secret key", modify: func(t *BucketTarget) { t.Credentials = &Credentials{AccessKey: "minioadmin"} }, missing: []string{"credentials"}},
+		{
+			name:    "empty",
+			modify:  func(t *BucketTarget) { *t = BucketTarget{} },
+			missing: []string{"endpoint", "credentials", "target bucket", "type"},
+		},
+		{
+			name:    "no target bucket and type",
+			modify:  func(t *BucketTarget) { t.TargetBucket, t.Type = "", "" },
+			missing: []string{"target bucket", "type"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			target := valid
+			tc.modify(&target)
+			err := target.Validate()
+			if tc.missing == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			var targetErr InvalidBucketTargetError
+			if !errors.As(err, &targetErr) {
+				t.Fatalf("expected InvalidBucketTargetError, got %v", err)
+			}
+			if !reflect.DeepEqual(targetErr.Missing, tc.missing) {
+				t.Errorf("expected missing %q, got %q", tc.missing, targetErr.Missing)
+			}
+		})
+	}
+}
+
+func TestSetRemoteTargetValidates(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request for an invalid target %s", r.URL)
+	})
+	_, err := adm.SetRemoteTarget(context.Background(), "bucket", &BucketTarget{Endpoint: "site2:9000"})
+	var targetErr InvalidBucketTargetError
+	if !errors.As(err, &targetErr) {
+		t.Fatalf("expected InvalidBucketTargetError, got %v", err)
+	}
+	if err.Error() != "invalid remote target, missing credentials, target bucket, type" {
+		t.Errorf("unexpected error message %q", err)
+	}
+}
+
+func TestListRemoteTargetsByType(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query(); q.Get("bucket") != "bucket" || q.Get("type") != "" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		w.Write([]byte(`[
+	{"sourcebucket":"bucket","endpoint":"site1:9000","targetbucket":"target-1","arn":"arn:minio:replication::1:target-1","type":"replication"},
+	{"sourcebucket":"bucket","endpoint":"tier:9000","targetbucket":"warm","arn":"arn:minio:ilm::2:warm","type":"ilm"},
+	{"sourcebucket":"bucket","endpoint":"site2:9000","targetbucket":"target-2","arn":"arn:minio:replication::3:target-2","type":"replication"}
+]`))
+	})
+
+	arns := func(targets []BucketTarget) (arns []string) {
+		for _, t := range targets {
+			arns = append(arns, t.Arn)
+		}
+		return arns
+	}
+	testCases := []struct {
+		name     string
+		arnTypes []string
+		want     []string
+	}{
+		{name: "replication", arnTypes: []string{"replication"}, want: []string{"arn:minio:replication::1:target-1", "arn:minio:replication::3:target-2"}},
+		{name: "ilm", arnTypes: []string{"ilm"}, want: []string{"arn:minio:ilm::2:warm"}},
+		{name: "unknown", arnTypes: []string{"lambda"}},
+		{name: "all", want: []string{"arn:minio:replication::1:target-1", "arn:minio:ilm::2:warm", "arn:minio:replication::3:target-2"}},
+		{name: "empty", arnTypes: []string{""}, want: []string{"arn:minio:replication::1:target-1", "arn:minio:ilm::2:warm", "arn:minio:replication::3:target-2"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			targets, err := adm.ListRemoteTargetsByType(context.Background(), "bucket", tc.arnTypes...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := arns(targets); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -24,7 +24,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
+
+	"github.com/minio/minio-go/v7/pkg/replication"
 )
 
 //go:generate msgp -file $GOFILE
@@ -222,3 +225,48 @@ func (r RStat) Add(r1 RStat) RStat {
 		Bytes: r.Bytes + r1.Bytes,
 	}
 }
+
+// ReplicationRuleForObject returns the rule of the bucket replication config
+// cfg that applies to the object with the given name and tags, matching rules
+// the way the server does: a rule applies if it is enabled, its prefix is a
+// prefix of object and all of its tags are set on the object with the same
+// value. Of the applicable rules the one with the highest priority is
+// returned. False is returned if no rule applies.
+func ReplicationRuleForObject(cfg replication.Config, object string, tags map[string]string) (replication.Rule, bool) {
+	var (
+		match replication.Rule
+		found bool
+	)
+	for _, rule := range cfg.Rules {
+		if rule.Status != replication.Enabled || !replicationRuleMatches(rule, object, tags) {
+			continue
+		}
+		if !found || rule.Priority > match.Priority {
+			match, found = rule, true
+		}
+	}
+	return match, found
+}
+
+// replicationRuleMatches returns whether the prefix and tags of the filter of
+// rule match the object.
+func replicationRuleMatches(rule replication.Rule, object string, tags map[string]string) bool {
+	prefix := rule.Filter.Prefix
+	if prefix == "" {
+		prefix = rule.Filter.And.Prefix
+	}
+	if !strings.HasPrefix(object, prefix) {
+		return false
+	}
+	if tag := rule.Filter.Tag; tag.Key != "" {
+		if v, ok := tags[tag.Key]; !ok || v != tag.Value {
+			return false
+		}
+	}
+	for _, tag := range rule.Filter.And.Tags {
+		if v, ok := tags[tag.Key]; !ok || v != tag.Value {
+			return false
+		}
+	}
+	return true
+}
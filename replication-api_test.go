@@ -0,0 +1,89 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/replication"
+)
+
+func TestReplicationRuleForObject(t *testing.T) {
+	cfg := replication.Config{
+		Rules: []replication.Rule{
+			{
+				ID:       "all",
+				Status:   replication.Enabled,
+				Priority: 1,
+			},
+			{
+				ID:       "photos",
+				Status:   replication.Enabled,
+				Priority: 2,
+				Filter:   replication.Filter{Prefix: "photos/"},
+			},
+			{
+				ID:       "photos-disabled",
+				Status:   replication.Disabled,
+				Priority: 10,
+				Filter:   replication.Filter{Prefix: "photos/raw/"},
+			},
+			{
+				ID:       "confidential",
+				Status:   replication.Enabled,
+				Priority: 3,
+				Filter:   replication.Filter{Tag: replication.Tag{Key: "class", Value: "confidential"}},
+			},
+			{
+				ID:       "eu-docs",
+				Status:   replication.Enabled,
+				Priority: 4,
+				Filter: replication.Filter{And: replication.And{
+					Prefix: "docs/",
+					Tags:   []replication.Tag{{Key: "region", Value: "eu"}, {Key: "class", Value: "confidential"}},
+				}},
+			},
+		},
+	}
+	tests := []struct {
+		object string
+		tags   map[string]string
+		want   string
+	}{
+		{object: "photos/a.jpg", want: "photos"},
+		{object: "photos/raw/a.cr2", want: "photos"},
+		{object: "videos/a.mp4", want: "all"},
+		{object: "videos/a.mp4", tags: map[string]string{"class": "confidential"}, want: "confidential"},
+		{object: "videos/a.mp4", tags: map[string]string{"class": "public"}, want: "all"},
+		{object: "docs/a.pdf", tags: map[string]string{"class": "confidential", "region": "eu"}, want: "eu-docs"},
+		{object: "docs/a.pdf", tags: map[string]string{"class": "confidential", "region": "us"}, want: "confidential"},
+	}
+	for _, test := range tests {
+		rule, ok := ReplicationRuleForObject(cfg, test.object, test.tags)
+		if !ok || rule.ID != test.want {
+			t.Errorf("%s %v: expected rule %q, got %q (found %v)", test.object, test.tags, test.want, rule.ID, ok)
+		}
+	}
+
+	cfg.Rules = cfg.Rules[1:]
+	if rule, ok := ReplicationRuleForObject(cfg, "videos/a.mp4", nil); ok {
+		t.Errorf("expected no rule, got %q", rule.ID)
+	}
+}
@@ -0,0 +1,147 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config keys of the scanner sub-system.
+const (
+	ScannerSpeedKey          = "speed"
+	ScannerIdleSpeedKey      = "idle_speed"
+	ScannerCycleKey          = "cycle"
+	ScannerExcessVersionsKey = "excess_versions"
+	ScannerExcessFoldersKey  = "excess_folders"
+)
+
+// ScannerSpeed is a preset controlling how aggressively the scanner runs.
+type ScannerSpeed string
+
+// Scanner speed presets.
+const (
+	ScannerSpeedFastest ScannerSpeed = "fastest"
+	ScannerSpeedFast    ScannerSpeed = "fast"
+	ScannerSpeedDefault ScannerSpeed = "default"
+	ScannerSpeedSlow    ScannerSpeed = "slow"
+	ScannerSpeedSlowest ScannerSpeed = "slowest"
+)
+
+// Valid returns whether s is a known scanner speed preset.
+func (s ScannerSpeed) Valid() bool {
+	switch s {
+	case ScannerSpeedFastest, ScannerSpeedFast, ScannerSpeedDefault, ScannerSpeedSlow, ScannerSpeedSlowest:
+		return true
+	}
+	return false
+}
+
+// ScannerConfig holds the configuration of the data scanner.
+//
+// The effect of these settings can be observed through the scanner
+// section of the realtime metrics (MetricsScanner): a slower speed
+// lowers the per minute action counts in ScannerMetrics.LastMinute and
+// lengthens the time between the cycle completions it reports.
+type ScannerConfig struct {
+	// Speed is the speed preset used while the cluster serves requests.
+	Speed ScannerSpeed `json:"speed"`
+
+	// IdleSpeed is the speed preset used while the cluster is idle.
+	// Empty means Speed is used at all times.
+	IdleSpeed ScannerSpeed `json:"idleSpeed,omitempty"`
+
+	// Cycle is the minimum time between the start of two scanner cycles.
+	// Zero keeps the server default.
+	Cycle time.Duration `json:"cycle"`
+
+	// ExcessVersions is the number of versions of an object above which
+	// the scanner reports an excess versions event. Zero keeps the server
+	// default.
+	ExcessVersions int `json:"excessVersions"`
+
+	// ExcessFolders is the number of sub-folders of a prefix above which
+	// the scanner reports an excess folders event. Zero keeps the server
+	// default.
+	ExcessFolders int `json:"excessFolders"`
+}
+
+// Validate returns an error if the scanner configuration is invalid.
+func (c ScannerConfig) Validate() error {
+	if !c.Speed.Valid() {
+		return fmt.Errorf("invalid scanner %s value %q", ScannerSpeedKey, c.Speed)
+	}
+	if c.IdleSpeed != "" && !c.IdleSpeed.Valid() {
+		return fmt.Errorf("invalid scanner %s value %q", ScannerIdleSpeedKey, c.IdleSpeed)
+	}
+	if c.Cycle < 0 {
+		return errors.New("scanner cycle must not be negative")
+	}
+	if c.ExcessVersions < 0 || c.ExcessFolders < 0 {
+		return errors.New("scanner excess thresholds must not be negative")
+	}
+	return nil
+}
+
+func (c ScannerConfig) kvs() []string {
+	return []string{
+		ScannerSpeedKey + KvSeparator + string(c.Speed),
+		ScannerIdleSpeedKey + KvSeparator + string(c.IdleSpeed),
+		ScannerCycleKey + KvSeparator + formatDuration(c.Cycle),
+		ScannerExcessVersionsKey + KvSeparator + formatInt(c.ExcessVersions),
+		ScannerExcessFoldersKey + KvSeparator + formatInt(c.ExcessFolders),
+	}
+}
+
+func parseScannerConfig(cfg SubsysConfig) (c ScannerConfig, err error) {
+	speed, _ := cfg.Lookup(ScannerSpeedKey)
+	c.Speed = ScannerSpeed(speed)
+	idleSpeed, _ := cfg.Lookup(ScannerIdleSpeedKey)
+	c.IdleSpeed = ScannerSpeed(idleSpeed)
+	if c.Cycle, err = lookupDuration(cfg, ScannerCycleKey); err != nil {
+		return c, err
+	}
+	if c.ExcessVersions, err = lookupInt(cfg, ScannerExcessVersionsKey); err != nil {
+		return c, err
+	}
+	if c.ExcessFolders, err = lookupInt(cfg, ScannerExcessFoldersKey); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// GetScannerConfig - returns the configuration of the data scanner.
+func (adm *AdminClient) GetScannerConfig(ctx context.Context) (ScannerConfig, error) {
+	cfg, err := adm.getSubsysConfig(ctx, ScannerSubSys)
+	if err != nil {
+		return ScannerConfig{}, err
+	}
+	return parseScannerConfig(cfg)
+}
+
+// SetScannerConfig - sets the configuration of the data scanner.
+func (adm *AdminClient) SetScannerConfig(ctx context.Context, c ScannerConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	return adm.setSubsysConfig(ctx, ScannerSubSys, c.kvs()...)
+}
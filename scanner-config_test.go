@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScannerConfigRoundTrip(t *testing.T) {
+	tests := []ScannerConfig{
+		{Speed: ScannerSpeedDefault, Cycle: time.Minute, ExcessVersions: 100, ExcessFolders: 50000},
+		{Speed: ScannerSpeedSlowest, IdleSpeed: ScannerSpeedFastest, Cycle: time.Hour},
+		{Speed: ScannerSpeedFast},
+	}
+	for _, want := range tests {
+		if err := want.Validate(); err != nil {
+			t.Fatalf("%+v: unexpected validation error: %v", want, err)
+		}
+		cfgs, err := ParseServerConfigOutput(ScannerSubSys + " " + strings.Join(want.kvs(), " "))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parseScannerConfig(cfgs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestScannerConfigKVsZero(t *testing.T) {
+	got := strings.Join(ScannerConfig{Speed: ScannerSpeedFast}.kvs(), " ")
+	want := "speed=fast idle_speed= cycle= excess_versions= excess_folders="
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestScannerConfigValidate(t *testing.T) {
+	tests := []ScannerConfig{
+		{},
+		{Speed: "turbo"},
+		{Speed: ScannerSpeedFast, IdleSpeed: "turbo"},
+		{Speed: ScannerSpeedFast, Cycle: -time.Second},
+		{Speed: ScannerSpeedFast, ExcessVersions: -1},
+	}
+	for _, c := range tests {
+		if err := c.Validate(); err == nil {
+			t.Errorf("%+v: expected validation error", c)
+		}
+	}
+}
+
+func TestGetSetScannerConfig(t *testing.T) {
+	adm, received := newTestConfigKVClient(t, ScannerSubSys, ScannerSubSys+" speed=slow idle_speed=fast cycle=1m excess_versions=100 excess_folders=50000")
+	got, err := adm.GetScannerConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ScannerConfig{Speed: ScannerSpeedSlow, IdleSpeed: ScannerSpeedFast, Cycle: time.Minute, ExcessVersions: 100, ExcessFolders: 50000}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if err = adm.SetScannerConfig(context.Background(), ScannerConfig{Speed: ScannerSpeedFastest, Cycle: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	if want := ScannerSubSys + " speed=fastest idle_speed= cycle=1h0m0s excess_versions= excess_folders="; *received != want {
+		t.Errorf("expected %q, got %q", want, *received)
+	}
+}
@@ -154,8 +154,17 @@ type ServiceTraceOpts struct {
 	KMS               bool
 	Formatting        bool
 
+	// Types enables additional trace types, combined with the ones above.
+	Types TraceType
+
+	// OnlyErrors only returns failed calls. Traces without an error
+	// are also dropped on the client, for servers that ignore it.
 	OnlyErrors bool
 	Threshold  time.Duration
+
+	// Anonymize hides object names and client addresses
+	// in the returned traces, see TraceInfo.Anonymize.
+	Anonymize bool
 }
 
 // TraceTypes returns the enabled traces as a bitfield value.
@@ -179,6 +188,7 @@ func (t ServiceTraceOpts) TraceTypes() TraceType {
 	tt.SetIf(t.ILM, TraceILM)
 	tt.SetIf(t.KMS, TraceKMS)
 	tt.SetIf(t.Formatting, TraceFormatting)
+	tt.Merge(t.Types)
 
 	return tt
 }
@@ -188,23 +198,24 @@ func (t ServiceTraceOpts) AddParams(u url.Values) {
 	u.Set("err", strconv.FormatBool(t.OnlyErrors))
 	u.Set("threshold", t.Threshold.String())
 
-	u.Set("s3", strconv.FormatBool(t.S3))
-	u.Set("internal", strconv.FormatBool(t.Internal))
-	u.Set("storage", strconv.FormatBool(t.Storage))
-	u.Set("os", strconv.FormatBool(t.OS))
-	u.Set("scanner", strconv.FormatBool(t.Scanner))
-	u.Set("decommission", strconv.FormatBool(t.Decommission))
-	u.Set("healing", strconv.FormatBool(t.Healing))
-	u.Set("batch-replication", strconv.FormatBool(t.BatchAll || t.BatchReplication))
-	u.Set("batch-keyrotation", strconv.FormatBool(t.BatchAll || t.BatchKeyRotation))
-	u.Set("batch-expire", strconv.FormatBool(t.BatchAll || t.BatchExpire))
-	u.Set("rebalance", strconv.FormatBool(t.Rebalance))
-	u.Set("replication-resync", strconv.FormatBool(t.ReplicationResync))
-	u.Set("bootstrap", strconv.FormatBool(t.Bootstrap))
-	u.Set("ftp", strconv.FormatBool(t.FTP))
-	u.Set("ilm", strconv.FormatBool(t.ILM))
-	u.Set("kms", strconv.FormatBool(t.KMS))
-	u.Set("formatting", strconv.FormatBool(t.Formatting))
+	tt := t.TraceTypes()
+	u.Set("s3", strconv.FormatBool(tt.Contains(TraceS3)))
+	u.Set("internal", strconv.FormatBool(tt.Contains(TraceInternal)))
+	u.Set("storage", strconv.FormatBool(tt.Contains(TraceStorage)))
+	u.Set("os", strconv.FormatBool(tt.Contains(TraceOS)))
+	u.Set("scanner", strconv.FormatBool(tt.Contains(TraceScanner)))
+	u.Set("decommission", strconv.FormatBool(tt.Contains(TraceDecommission)))
+	u.Set("healing", strconv.FormatBool(tt.Contains(TraceHealing)))
+	u.Set("batch-replication", strconv.FormatBool(tt.Contains(TraceBatchReplication)))
+	u.Set("batch-keyrotation", strconv.FormatBool(tt.Contains(TraceBatchKeyRotation)))
+	u.Set("batch-expire", strconv.FormatBool(tt.Contains(TraceBatchExpire)))
+	u.Set("rebalance", strconv.FormatBool(tt.Contains(TraceRebalance)))
+	u.Set("replication-resync", strconv.FormatBool(tt.Contains(TraceReplicationResync)))
+	u.Set("bootstrap", strconv.FormatBool(tt.Contains(TraceBootstrap)))
+	u.Set("ftp", strconv.FormatBool(tt.Contains(TraceFTP)))
+	u.Set("ilm", strconv.FormatBool(tt.Contains(TraceILM)))
+	u.Set("kms", strconv.FormatBool(tt.Contains(TraceKMS)))
+	u.Set("formatting", strconv.FormatBool(tt.Contains(TraceFormatting)))
 }
 
 // ParseParams will parse parameters and set them to t.
@@ -301,11 +312,18 @@ func (adm AdminClient) ServiceTrace(ctx context.Context, opts ServiceTraceOpts)
 					info.Path = info.StorageStats.Path
 					info.Duration = info.StorageStats.Duration
 				}
+				trace := info.TraceInfo
+				if opts.OnlyErrors && !trace.failed() {
+					continue
+				}
+				if opts.Anonymize {
+					trace = trace.Anonymize()
+				}
 				select {
 				case <-ctx.Done():
 					closeResponse(resp)
 					return
-				case traceInfoCh <- ServiceTraceInfo{Trace: info.TraceInfo}:
+				case traceInfoCh <- ServiceTraceInfo{Trace: trace}:
 				}
 			}
 		}
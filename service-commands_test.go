@@ -0,0 +1,86 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestServiceTraceOptsTypes(t *testing.T) {
+	opts := ServiceTraceOpts{Types: TraceS3 | TraceInternal, OnlyErrors: true}
+	if tt := opts.TraceTypes(); tt != TraceS3|TraceInternal {
+		t.Errorf("expected trace types %v, got %v", TraceS3|TraceInternal, tt)
+	}
+
+	u := make(url.Values)
+	opts.AddParams(u)
+	for key, want := range map[string]string{
+		"s3":       "true",
+		"internal": "true",
+		"storage":  "false",
+		"os":       "false",
+		"scanner":  "false",
+		"err":      "true",
+	} {
+		if got := u.Get(key); got != want {
+			t.Errorf("expected %s=%s, got %s=%s", key, want, key, got)
+		}
+	}
+
+	var parsed ServiceTraceOpts
+	if err := parsed.ParseParams(&http.Request{Form: u}); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.TraceTypes() != opts.TraceTypes() || !parsed.OnlyErrors {
+		t.Errorf("expected %+v after parsing, got %+v", opts, parsed)
+	}
+}
+
+func TestServiceTraceOnlyErrors(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// Behave like a server that ignores the err parameter.
+		enc := json.NewEncoder(w)
+		for _, status := range []int{http.StatusOK, http.StatusNoContent, http.StatusNotFound} {
+			enc.Encode(TraceInfo{
+				TraceType: TraceS3,
+				FuncName:  "s3.GetObject",
+				HTTP:      &TraceHTTPStats{RespInfo: TraceResponseInfo{StatusCode: status}},
+			})
+		}
+		enc.Encode(TraceInfo{TraceType: TraceInternal, FuncName: "minio.Lock", Error: "lock timeout"})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	traceCh := adm.ServiceTrace(ctx, ServiceTraceOpts{Types: TraceS3 | TraceInternal, OnlyErrors: true})
+	for _, want := range []string{"s3.GetObject", "minio.Lock"} {
+		info := <-traceCh
+		if info.Err != nil {
+			t.Fatal(info.Err)
+		}
+		if info.Trace.FuncName != want || !info.Trace.failed() {
+			t.Errorf("expected failed %s trace, got %+v", want, info.Trace)
+		}
+	}
+}
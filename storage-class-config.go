@@ -0,0 +1,139 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Config keys of the storage_class sub-system.
+const (
+	StorageClassStandardKey = "standard"
+	StorageClassRRSKey      = "rrs"
+)
+
+// storageClassECPrefix is the prefix of storage class parity values, as in "EC:4".
+const storageClassECPrefix = "EC:"
+
+// StorageClassConfig holds the parity settings of the storage classes.
+// A parity of zero leaves the server default in place.
+type StorageClassConfig struct {
+	StandardParity int `json:"standardParity"`
+	RRSParity      int `json:"rrsParity"`
+}
+
+// Validate returns an error if the parities are not valid for
+// erasure sets of the given number of drives.
+func (c StorageClassConfig) Validate(drivesPerSet int) error {
+	if c.StandardParity < 0 || c.RRSParity < 0 {
+		return errors.New("storage class parity must not be negative")
+	}
+	maxParity := drivesPerSet / 2
+	if c.StandardParity > maxParity {
+		return fmt.Errorf("standard storage class parity %d exceeds %d for %d drives per set",
+			c.StandardParity, maxParity, drivesPerSet)
+	}
+	if c.RRSParity > maxParity {
+		return fmt.Errorf("reduced redundancy storage class parity %d exceeds %d for %d drives per set",
+			c.RRSParity, maxParity, drivesPerSet)
+	}
+	if c.StandardParity > 0 && c.RRSParity > c.StandardParity {
+		return fmt.Errorf("reduced redundancy storage class parity %d exceeds standard parity %d",
+			c.RRSParity, c.StandardParity)
+	}
+	return nil
+}
+
+func formatParity(parity int) string {
+	if parity == 0 {
+		return ""
+	}
+	return storageClassECPrefix + strconv.Itoa(parity)
+}
+
+func lookupParity(cfg SubsysConfig, key string) (int, error) {
+	v, _ := cfg.Lookup(key)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(v, storageClassECPrefix))
+	if err != nil || !strings.HasPrefix(v, storageClassECPrefix) {
+		return 0, fmt.Errorf("invalid %s %s value %q: expected EC:<parity>", cfg.SubSystem, key, v)
+	}
+	return n, nil
+}
+
+func (c StorageClassConfig) kvs() []string {
+	return []string{
+		StorageClassStandardKey + KvSeparator + formatParity(c.StandardParity),
+		StorageClassRRSKey + KvSeparator + formatParity(c.RRSParity),
+	}
+}
+
+func parseStorageClassConfig(cfg SubsysConfig) (c StorageClassConfig, err error) {
+	if c.StandardParity, err = lookupParity(cfg, StorageClassStandardKey); err != nil {
+		return c, err
+	}
+	if c.RRSParity, err = lookupParity(cfg, StorageClassRRSKey); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// minDrivesPerSet returns the smallest erasure set size across all pools.
+func (b BackendInfo) minDrivesPerSet() int {
+	n := 0
+	for _, drives := range b.DrivesPerSet {
+		if n == 0 || drives < n {
+			n = drives
+		}
+	}
+	return n
+}
+
+// GetStorageClassConfig - returns the parity settings of the storage classes.
+func (adm *AdminClient) GetStorageClassConfig(ctx context.Context) (StorageClassConfig, error) {
+	cfg, err := adm.getSubsysConfig(ctx, StorageClassSubSys)
+	if err != nil {
+		return StorageClassConfig{}, err
+	}
+	return parseStorageClassConfig(cfg)
+}
+
+// SetStorageClassConfig - sets the parity settings of the storage classes,
+// after validating them against the erasure set size of the cluster.
+func (adm *AdminClient) SetStorageClassConfig(ctx context.Context, c StorageClassConfig) error {
+	info, err := adm.StorageInfo(ctx)
+	if err != nil {
+		return err
+	}
+	drivesPerSet := info.Backend.minDrivesPerSet()
+	if drivesPerSet == 0 {
+		return errors.New("storage classes are only supported on erasure coded deployments")
+	}
+	if err := c.Validate(drivesPerSet); err != nil {
+		return err
+	}
+	return adm.setSubsysConfig(ctx, StorageClassSubSys, c.kvs()...)
+}
@@ -0,0 +1,96 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStorageClassConfigRoundTrip(t *testing.T) {
+	tests := []StorageClassConfig{
+		{},
+		{StandardParity: 4},
+		{StandardParity: 4, RRSParity: 2},
+		{StandardParity: 8, RRSParity: 1},
+	}
+	for _, want := range tests {
+		if err := want.Validate(16); err != nil {
+			t.Fatalf("%+v: unexpected validation error: %v", want, err)
+		}
+		cfgs, err := ParseServerConfigOutput(StorageClassSubSys + " " + strings.Join(want.kvs(), " ") + " optimize=availability")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parseStorageClassConfig(cfgs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestStorageClassConfigValidate(t *testing.T) {
+	tests := []struct {
+		cfg          StorageClassConfig
+		drivesPerSet int
+		valid        bool
+	}{
+		{cfg: StorageClassConfig{StandardParity: 2, RRSParity: 1}, drivesPerSet: 4, valid: true},
+		{cfg: StorageClassConfig{StandardParity: 8, RRSParity: 8}, drivesPerSet: 16, valid: true},
+		{cfg: StorageClassConfig{RRSParity: 2}, drivesPerSet: 4, valid: true},
+		{cfg: StorageClassConfig{StandardParity: 3}, drivesPerSet: 4, valid: false},
+		{cfg: StorageClassConfig{StandardParity: 9}, drivesPerSet: 16, valid: false},
+		{cfg: StorageClassConfig{RRSParity: 3}, drivesPerSet: 4, valid: false},
+		{cfg: StorageClassConfig{StandardParity: 2, RRSParity: 4}, drivesPerSet: 16, valid: false},
+		{cfg: StorageClassConfig{StandardParity: -1}, drivesPerSet: 16, valid: false},
+	}
+	for _, test := range tests {
+		err := test.cfg.Validate(test.drivesPerSet)
+		if test.valid && err != nil {
+			t.Errorf("%+v with %d drives: unexpected error: %v", test.cfg, test.drivesPerSet, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%+v with %d drives: expected error", test.cfg, test.drivesPerSet)
+		}
+	}
+}
+
+func TestParseStorageClassConfigInvalid(t *testing.T) {
+	cfgs, err := ParseServerConfigOutput(StorageClassSubSys + " standard=4 rrs=EC:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseStorageClassConfig(cfgs[0]); err == nil {
+		t.Error("expected error for parity without EC: prefix")
+	}
+}
+
+func TestBackendInfoMinDrivesPerSet(t *testing.T) {
+	b := BackendInfo{DrivesPerSet: []int{16, 8, 12}}
+	if got := b.minDrivesPerSet(); got != 8 {
+		t.Errorf("expected 8, got %d", got)
+	}
+	if got := (BackendInfo{}).minDrivesPerSet(); got != 0 {
+		t.Errorf("expected 0, got %d", got)
+	}
+}
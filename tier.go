@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -33,13 +34,23 @@ import (
 // tierAPI is API path prefix for tier related admin APIs
 const tierAPI = "tier"
 
+// AddTierOpts - options for adding a remote tier
+type AddTierOpts struct {
+	// IgnoreInUse adds the tier even if it's being used by another MinIO deployment.
+	IgnoreInUse bool
+	// DryRun only verifies that the remote tier is reachable with the
+	// given credentials, without adding it. Older servers ignore it and
+	// add the tier, callers should also verify it with VerifyTier.
+	DryRun bool
+}
+
 // AddTierIgnoreInUse adds a new remote tier, ignoring if it's being used by another MinIO deployment.
 func (adm *AdminClient) AddTierIgnoreInUse(ctx context.Context, cfg *TierConfig) error {
-	return adm.addTier(ctx, cfg, true)
+	return adm.AddTierV2(ctx, cfg, AddTierOpts{IgnoreInUse: true})
 }
 
-// AddTier adds a new remote tier.
-func (adm *AdminClient) addTier(ctx context.Context, cfg *TierConfig, ignoreInUse bool) error {
+// AddTierV2 adds a new remote tier, or only verifies it if opts.DryRun is set.
+func (adm *AdminClient) AddTierV2(ctx context.Context, cfg *TierConfig, opts AddTierOpts) error {
 	data, err := json.Marshal(cfg)
 	if err != nil {
 		return err
@@ -51,7 +62,10 @@ func (adm *AdminClient) addTier(ctx context.Context, cfg *TierConfig, ignoreInUs
 	}
 
 	queryVals := url.Values{}
-	queryVals.Set("force", strconv.FormatBool(ignoreInUse))
+	queryVals.Set("force", strconv.FormatBool(opts.IgnoreInUse))
+	if opts.DryRun {
+		queryVals.Set("dry-run", "true")
+	}
 	reqData := requestData{
 		relPath:     path.Join(adminAPIPrefix, tierAPI),
 		content:     encData,
@@ -73,10 +87,10 @@ func (adm *AdminClient) addTier(ctx context.Context, cfg *TierConfig, ignoreInUs
 
 // AddTier adds a new remote tier.
 func (adm *AdminClient) AddTier(ctx context.Context, cfg *TierConfig) error {
-	return adm.addTier(ctx, cfg, false)
+	return adm.AddTierV2(ctx, cfg, AddTierOpts{})
 }
 
-// ListTiers returns a list of remote tiers configured.
+// ListTiers returns a list of remote tiers configured, sorted by name.
 func (adm *AdminClient) ListTiers(ctx context.Context) ([]*TierConfig, error) {
 	reqData := requestData{
 		relPath: path.Join(adminAPIPrefix, tierAPI),
@@ -104,9 +118,27 @@ func (adm *AdminClient) ListTiers(ctx context.Context) ([]*TierConfig, error) {
 		return tiers, err
 	}
 
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].Name < tiers[j].Name
+	})
 	return tiers, nil
 }
 
+// ListTiersByType returns the remote tiers of type t configured, sorted by name.
+func (adm *AdminClient) ListTiersByType(ctx context.Context, t TierType) ([]*TierConfig, error) {
+	tiers, err := adm.ListTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filtered := tiers[:0]
+	for _, tier := range tiers {
+		if tier.Type == t {
+			filtered = append(filtered, tier)
+		}
+	}
+	return filtered, nil
+}
+
 // TierCreds is used to pass remote tier credentials in a tier-edit operation.
 type TierCreds struct {
 	AccessKey string `json:"access,omitempty"`
@@ -20,9 +20,13 @@
 package madmin
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"reflect"
 	"testing"
 )
@@ -187,3 +191,89 @@ func TestGCSStorageClass(t *testing.T) {
 		t.Fatalf("got != want, got = %v want = %v", *got, *want)
 	}
 }
+
+func TestAddTierDryRun(t *testing.T) {
+	cfg, err := NewTierS3("WARM-S3", "accessKey", "secretKey", "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var query url.Values
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/minio/admin/v3/tier" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		query = r.URL.Query()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err = adm.AddTierV2(context.Background(), cfg, AddTierOpts{DryRun: true}); err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("dry-run") != "true" || query.Get("force") != "false" {
+		t.Errorf("unexpected query %q", query.Encode())
+	}
+
+	if err = adm.AddTier(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if query.Has("dry-run") {
+		t.Errorf("unexpected dry-run in query %q", query.Encode())
+	}
+}
+
+func TestListTiersByType(t *testing.T) {
+	newTier := func(tier *TierConfig, err error) *TierConfig {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tier
+	}
+	tiers := []*TierConfig{
+		newTier(NewTierS3("WARM-S3-B", "accessKey", "secretKey", "bucket")),
+		newTier(NewTierAzure("WARM-AZ", "accessKey", "secretKey", "bucket")),
+		newTier(NewTierMinIO("WARM-MINIO", "https://minio:9000", "accessKey", "secretKey", "bucket")),
+		newTier(NewTierS3("WARM-S3-A", "accessKey", "secretKey", "bucket")),
+		newTier(NewTierGCS("WARM-GCS", []byte("credentials"), "bucket")),
+	}
+	data, err := json.Marshal(tiers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/tier" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Write(data)
+	})
+
+	names := func(tiers []*TierConfig) (names []string) {
+		for _, tier := range tiers {
+			names = append(names, tier.Name)
+		}
+		return names
+	}
+	all, err := adm.ListTiers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"WARM-AZ", "WARM-GCS", "WARM-MINIO", "WARM-S3-A", "WARM-S3-B"}; !reflect.DeepEqual(names(all), want) {
+		t.Errorf("expected tiers %q, got %q", want, names(all))
+	}
+
+	s3Tiers, err := adm.ListTiersByType(context.Background(), S3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"WARM-S3-A", "WARM-S3-B"}; !reflect.DeepEqual(names(s3Tiers), want) {
+		t.Errorf("expected S3 tiers %q, got %q", want, names(s3Tiers))
+	}
+
+	minioTiers, err := adm.ListTiersByType(context.Background(), MinIO)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(minioTiers) != 1 || minioTiers[0].Type != MinIO || minioTiers[0].MinIO.Endpoint != "https://minio:9000" {
+		t.Errorf("unexpected MinIO tiers %+v", minioTiers)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -65,6 +66,11 @@ func (l LockEntries) Swap(i, j int) {
 type TopLockOpts struct {
 	Count int
 	Stale bool
+
+	// OlderThan only returns locks held for longer than this.
+	OlderThan time.Duration
+	// Bucket only returns locks on this bucket or its objects.
+	Bucket string
 }
 
 // ForceUnlock force unlocks input paths...
@@ -91,8 +97,33 @@ func (adm *AdminClient) ForceUnlock(ctx context.Context, paths ...string) error
 	return nil
 }
 
+// forceUnlockWorkers is the number of resources ForceUnlockResults
+// unlocks concurrently.
+const forceUnlockWorkers = 8
+
+// ForceUnlockResults - force unlocks paths one by one, up to eight of them
+// concurrently, and returns the outcome of every path: nil if it was
+// unlocked, the error otherwise. The error is only set if the context was
+// cancelled before all paths were processed.
+func (adm *AdminClient) ForceUnlockResults(ctx context.Context, paths ...string) (map[string]error, error) {
+	errs := make([]error, len(paths))
+	runConcurrently(len(paths), forceUnlockWorkers, func(i int) {
+		if errs[i] = ctx.Err(); errs[i] != nil {
+			return
+		}
+		errs[i] = adm.ForceUnlock(ctx, paths[i])
+	})
+	results := make(map[string]error, len(paths))
+	for i, path := range paths {
+		results[path] = errs[i]
+	}
+	return results, ctx.Err()
+}
+
 // TopLocksWithOpts - returns the count number of oldest locks currently active on the server.
 // additionally we can also enable `stale` to get stale locks currently present on server.
+// The OlderThan and Bucket filters are applied by the client to the locks returned by
+// the server, so fewer than count locks may be returned.
 func (adm *AdminClient) TopLocksWithOpts(ctx context.Context, opts TopLockOpts) (LockEntries, error) {
 	// Execute GET on /minio/admin/v3/top/locks?count=10
 	// to get the 'count' number of oldest locks currently
@@ -122,11 +153,116 @@ func (adm *AdminClient) TopLocksWithOpts(ctx context.Context, opts TopLockOpts)
 	}
 
 	var lockEntries LockEntries
-	err = json.Unmarshal(response, &lockEntries)
-	return lockEntries, err
+	if err = json.Unmarshal(response, &lockEntries); err != nil {
+		return nil, err
+	}
+	return filterLocks(lockEntries, opts), nil
+}
+
+// filterLocks returns the locks matching the OlderThan and Bucket filters of opts.
+func filterLocks(locks LockEntries, opts TopLockOpts) LockEntries {
+	if opts.OlderThan <= 0 && opts.Bucket == "" {
+		return locks
+	}
+	filtered := locks[:0]
+	for _, l := range locks {
+		if opts.OlderThan > 0 && l.Elapsed <= opts.OlderThan {
+			continue
+		}
+		if opts.Bucket != "" && l.Resource != opts.Bucket && !strings.HasPrefix(l.Resource, opts.Bucket+"/") {
+			continue
+		}
+		filtered = append(filtered, l)
+	}
+	return filtered
 }
 
 // TopLocks - returns top '10' oldest locks currently active on the server.
 func (adm *AdminClient) TopLocks(ctx context.Context) (LockEntries, error) {
 	return adm.TopLocksWithOpts(ctx, TopLockOpts{Count: 10})
 }
+
+// LockOwner holds information about a holder of a lock.
+type LockOwner struct {
+	Node   string    `json:"node"`   // Server that owns the lock
+	Source string    `json:"source"` // Source at which lock was granted
+	UID    string    `json:"uid"`    // UID of the request holding the lock
+	Since  time.Time `json:"since"`  // When the lock was granted
+	Write  bool      `json:"write"`  // Whether it is a write lock
+}
+
+// lockSearchCount is the number of oldest locks searched by
+// LockOwners and ClearLocksOlderThan.
+const lockSearchCount = 10000
+
+// LockOwners - returns the holders of the locks on resource, a bucket
+// or bucket/object, oldest first. Only the oldest 10000 locks of the
+// cluster are searched.
+func (adm *AdminClient) LockOwners(ctx context.Context, resource string) ([]LockOwner, error) {
+	locks, err := adm.TopLocksWithOpts(ctx, TopLockOpts{Count: lockSearchCount})
+	if err != nil {
+		return nil, err
+	}
+	return lockOwners(locks, resource), nil
+}
+
+// lockOwners returns the holders of the locks on resource in locks.
+func lockOwners(locks LockEntries, resource string) []LockOwner {
+	sort.Sort(locks)
+	var owners []LockOwner
+	for _, l := range locks {
+		if l.Resource != resource {
+			continue
+		}
+		owners = append(owners, LockOwner{
+			Node:   l.Owner,
+			Source: l.Source,
+			UID:    l.ID,
+			Since:  l.Timestamp,
+			Write:  strings.EqualFold(l.Type, "write"),
+		})
+	}
+	return owners
+}
+
+// ClearLocksResult holds the result of ClearLocksOlderThan.
+type ClearLocksResult struct {
+	Cleared   int      `json:"cleared"`   // Number of resources force unlocked
+	Resources []string `json:"resources"` // Resources that were force unlocked
+}
+
+// ClearLocksOlderThan - force unlocks all resources with a lock held for
+// longer than age. Only the oldest 10000 locks of the cluster are searched.
+func (adm *AdminClient) ClearLocksOlderThan(ctx context.Context, age time.Duration) (ClearLocksResult, error) {
+	locks, err := adm.TopLocksWithOpts(ctx, TopLockOpts{Count: lockSearchCount})
+	if err != nil {
+		return ClearLocksResult{}, err
+	}
+	resources := locksOlderThan(locks, age)
+	if len(resources) == 0 {
+		return ClearLocksResult{}, nil
+	}
+	if err = adm.ForceUnlock(ctx, resources...); err != nil {
+		return ClearLocksResult{}, err
+	}
+	return ClearLocksResult{Cleared: len(resources), Resources: resources}, nil
+}
+
+// locksOlderThan returns the sorted resources in locks with
+// a lock held for longer than age.
+func locksOlderThan(locks LockEntries, age time.Duration) []string {
+	seen := make(map[string]struct{})
+	var resources []string
+	for _, l := range locks {
+		if l.Elapsed <= age {
+			continue
+		}
+		if _, ok := seen[l.Resource]; ok {
+			continue
+		}
+		seen[l.Resource] = struct{}{}
+		resources = append(resources, l.Resource)
+	}
+	sort.Strings(resources)
+	return resources
+}
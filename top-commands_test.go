@@ -0,0 +1,159 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLockOwners(t *testing.T) {
+	const input = `[
+	{"time":"2024-05-01T10:00:05Z","elapsed":5000000000,"resource":"bucket/other","type":"READ","source":"[cmd/object-handlers.go:100:GetObjectHandler()]","serverlist":["node1:9000"],"owner":"node1:9000","id":"b0f2c3a8","quorum":1},
+	{"time":"2024-05-01T10:00:00Z","elapsed":10000000000,"resource":"bucket/object","type":"WRITE","source":"[cmd/object-handlers.go:200:PutObjectHandler()]","serverlist":["node1:9000","node2:9000"],"owner":"node2:9000","id":"4f7c1d2e","quorum":2}
+]`
+	var locks LockEntries
+	if err := json.Unmarshal([]byte(input), &locks); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []LockOwner{{
+		Node:   "node2:9000",
+		Source: "[cmd/object-handlers.go:200:PutObjectHandler()]",
+		UID:    "4f7c1d2e",
+		Since:  time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		Write:  true,
+	}}
+	if got := lockOwners(locks, "bucket/object"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if got := lockOwners(locks, "bucket/missing"); got != nil {
+		t.Errorf("expected no owners, got %+v", got)
+	}
+}
+
+func TestClearLocksOlderThan(t *testing.T) {
+	var unlocked string
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/minio/admin/v3/top/locks":
+			if got := r.URL.Query().Get("count"); got != "10000" {
+				t.Errorf("unexpected count %q", got)
+			}
+			w.Write([]byte(`[
+	{"time":"2024-05-01T10:00:00Z","elapsed":600000000000,"resource":"bucket/stuck","type":"WRITE","owner":"node1:9000","id":"a"},
+	{"time":"2024-05-01T10:00:01Z","elapsed":599000000000,"resource":"bucket/stuck","type":"READ","owner":"node2:9000","id":"b"},
+	{"time":"2024-05-01T10:05:00Z","elapsed":300000000000,"resource":"bucket/also-stuck","type":"READ","owner":"node1:9000","id":"c"},
+	{"time":"2024-05-01T10:09:59Z","elapsed":1000000000,"resource":"bucket/busy","type":"WRITE","owner":"node2:9000","id":"d"}
+]`))
+		case "/minio/admin/v3/force-unlock":
+			unlocked = r.URL.Query().Get("paths")
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	})
+
+	result, err := adm.ClearLocksOlderThan(context.Background(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ClearLocksResult{Cleared: 2, Resources: []string{"bucket/also-stuck", "bucket/stuck"}}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("expected %+v, got %+v", want, result)
+	}
+	if unlocked != "bucket/also-stuck,bucket/stuck" {
+		t.Errorf("unexpected force unlocked paths %q", unlocked)
+	}
+
+	unlocked = ""
+	result, err = adm.ClearLocksOlderThan(context.Background(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Cleared != 0 || unlocked != "" {
+		t.Errorf("expected no locks cleared, got %+v, unlocked %q", result, unlocked)
+	}
+}
+
+func TestFilterLocks(t *testing.T) {
+	locks := LockEntries{
+		{Resource: "photos/a.jpg", Elapsed: 10 * time.Minute, ID: "old-photo"},
+		{Resource: "photos/b.jpg", Elapsed: 5 * time.Second, ID: "new-photo"},
+		{Resource: "photos", Elapsed: 20 * time.Minute, ID: "old-bucket"},
+		{Resource: "photos-archive/a.jpg", Elapsed: 30 * time.Minute, ID: "other-bucket"},
+		{Resource: "logs/a.log", Elapsed: time.Minute, ID: "old-log"},
+		{Resource: "photos/c.jpg", ID: "just-granted"},
+	}
+	tests := []struct {
+		name string
+		opts TopLockOpts
+		want []string
+	}{
+		{name: "no filter", opts: TopLockOpts{}, want: []string{"old-photo", "new-photo", "old-bucket", "other-bucket", "old-log", "just-granted"}},
+		{name: "older than", opts: TopLockOpts{OlderThan: time.Minute}, want: []string{"old-photo", "old-bucket", "other-bucket"}},
+		{name: "bucket", opts: TopLockOpts{Bucket: "photos"}, want: []string{"old-photo", "new-photo", "old-bucket", "just-granted"}},
+		{name: "bucket negative older than", opts: TopLockOpts{Bucket: "photos", OlderThan: -time.Minute}, want: []string{"old-photo", "new-photo", "old-bucket", "just-granted"}},
+		{name: "bucket older than", opts: TopLockOpts{Bucket: "photos", OlderThan: 30 * time.Second}, want: []string{"old-photo", "old-bucket"}},
+		{name: "none", opts: TopLockOpts{OlderThan: time.Hour}, want: nil},
+	}
+	for _, test := range tests {
+		in := append(LockEntries(nil), locks...)
+		var got []string
+		for _, l := range filterLocks(in, test.opts) {
+			got = append(got, l.ID)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestForceUnlockResults(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/force-unlock" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if r.URL.Query().Get("paths") == "bucket/b" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"Code":"XMinioAdminInvalidArgument","Message":"unable to unlock"}`))
+		}
+	})
+
+	results, err := adm.ForceUnlockResults(context.Background(), "bucket/a", "bucket/b", "bucket/c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %v", results)
+	}
+	for _, path := range []string{"bucket/a", "bucket/c"} {
+		if err, ok := results[path]; !ok || err != nil {
+			t.Errorf("%s: expected to be unlocked, got %v", path, err)
+		}
+	}
+	if code := ToErrorResponse(results["bucket/b"]).Code; code != "XMinioAdminInvalidArgument" {
+		t.Errorf("bucket/b: unexpected error %v", results["bucket/b"])
+	}
+}
@@ -20,8 +20,12 @@
 package madmin
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"math/bits"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -152,6 +156,161 @@ func (t TraceInfo) Mask() uint64 {
 	return t.TraceType.Mask()
 }
 
+// failed returns whether t records a failed call.
+func (t TraceInfo) failed() bool {
+	if t.Error != "" {
+		return true
+	}
+	return t.HTTP != nil && t.HTTP.RespInfo.StatusCode >= http.StatusBadRequest
+}
+
+// Anonymize returns a copy of t that can be shared without revealing
+// object names or client addresses. Everything in a path after the first
+// element (usually the bucket) and listing prefixes and markers are
+// replaced by a hash, the last octet of client IPv4 addresses and the
+// last 64 bits of IPv6 addresses are set to zero and request and
+// response bodies are removed. Headers are masked the same way, see
+// anonymizeHeaders, and credentials are removed from them.
+func (t TraceInfo) Anonymize() TraceInfo {
+	t.Path = anonymizePath(t.Path)
+	if t.HTTP != nil {
+		h := *t.HTTP
+		h.ReqInfo.Path = anonymizePath(h.ReqInfo.Path)
+		h.ReqInfo.RawQuery = anonymizeQuery(h.ReqInfo.RawQuery)
+		h.ReqInfo.Client = anonymizeAddr(h.ReqInfo.Client)
+		h.ReqInfo.Headers = anonymizeHeaders(h.ReqInfo.Headers)
+		h.ReqInfo.Body = nil
+		h.RespInfo.Headers = anonymizeHeaders(h.RespInfo.Headers)
+		h.RespInfo.Body = nil
+		t.HTTP = &h
+	}
+	if t.HealResult != nil {
+		hr := *t.HealResult
+		hr.Object = anonymizeName(hr.Object)
+		t.HealResult = &hr
+	}
+	return t
+}
+
+// anonymizedQueryParams are the query parameters that hold object names.
+var anonymizedQueryParams = []string{"prefix", "marker", "key-marker", "start-after"}
+
+// anonymizedRemovedHeaders are the headers that hold credentials.
+var anonymizedRemovedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"Forwarded",
+	"X-Amz-Security-Token",
+	"X-Amz-Server-Side-Encryption-Customer-Key",
+	"X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key",
+}
+
+// anonymizedAddrHeaders are the headers that hold client addresses.
+var anonymizedAddrHeaders = []string{"X-Forwarded-For", "X-Real-Ip"}
+
+// anonymizedPathHeaders are the headers that hold object paths.
+var anonymizedPathHeaders = []string{"X-Amz-Copy-Source", "Location"}
+
+// anonymizeHeaders returns a copy of h with credentials removed, client
+// addresses masked and object names and user metadata values hashed.
+func anonymizeHeaders(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	h = h.Clone()
+	for _, key := range anonymizedRemovedHeaders {
+		h.Del(key)
+	}
+	for _, key := range anonymizedAddrHeaders {
+		for i, v := range h.Values(key) {
+			addrs := strings.Split(v, ",")
+			for j, addr := range addrs {
+				addrs[j] = anonymizeAddr(strings.TrimSpace(addr))
+			}
+			h[key][i] = strings.Join(addrs, ", ")
+		}
+	}
+	for _, key := range anonymizedPathHeaders {
+		for i, v := range h.Values(key) {
+			if u, err := url.Parse(v); err == nil && u.Host != "" {
+				u.Path, u.RawPath, u.RawQuery = anonymizePath(u.Path), "", ""
+				h[key][i] = u.String()
+			} else {
+				h[key][i] = anonymizePath(v)
+			}
+		}
+	}
+	for key, values := range h {
+		if strings.HasPrefix(key, "X-Amz-Meta-") {
+			for i, v := range values {
+				values[i] = anonymizeName(v)
+			}
+		}
+	}
+	return h
+}
+
+// anonymizeName returns a short hash of name.
+func anonymizeName(name string) string {
+	if name == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:8])
+}
+
+// anonymizePath hashes everything after the first element of p.
+func anonymizePath(p string) string {
+	trimmed := strings.TrimPrefix(p, "/")
+	first, rest, ok := strings.Cut(trimmed, "/")
+	if !ok || rest == "" {
+		return p
+	}
+	return p[:len(p)-len(trimmed)] + first + "/" + anonymizeName(rest)
+}
+
+// anonymizeQuery hashes the values of the query parameters holding object names.
+func anonymizeQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return anonymizeName(rawQuery)
+	}
+	for _, key := range anonymizedQueryParams {
+		for i, v := range values[key] {
+			values[key][i] = anonymizeName(v)
+		}
+	}
+	return values.Encode()
+}
+
+// anonymizeAddr masks the host part of an IP address, keeping the port.
+func anonymizeAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		host, port = addr, ""
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return addr
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		masked := make(net.IP, len(ip4))
+		copy(masked, ip4)
+		masked[3] = 0
+		host = masked.String()
+	} else {
+		host = ip.Mask(net.CIDRMask(64, 128)).String()
+	}
+	if port == "" {
+		return host
+	}
+	return net.JoinHostPort(host, port)
+}
+
 // traceInfoLegacy - represents a trace record, additionally
 // also reports errors if any while listening on trace.
 // For minio versions before July 2022.
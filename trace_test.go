@@ -0,0 +1,147 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTraceInfoAnonymize(t *testing.T) {
+	trace := TraceInfo{
+		TraceType: TraceS3,
+		FuncName:  "s3.ListObjectsV2",
+		Path:      "/bucket/secret/object.txt",
+		HTTP: &TraceHTTPStats{
+			ReqInfo: TraceRequestInfo{
+				Path:     "/bucket/secret/object.txt",
+				RawQuery: "list-type=2&prefix=secret%2F",
+				Client:   "192.168.1.42:51234",
+				Headers: http.Header{
+					"Authorization":        {"AWS4-HMAC-SHA256 Credential=AKIAEXAMPLE/20240101/us-east-1/s3/aws4_request, Signature=abc"},
+					"X-Amz-Security-Token": {"token"},
+					"X-Forwarded-For":      {"203.0.113.7, 10.1.2.3"},
+					"X-Real-Ip":            {"203.0.113.7"},
+					"X-Amz-Copy-Source":    {"/bucket/secret/source.txt?versionId=1"},
+					"X-Amz-Meta-Owner":     {"secret"},
+					"Content-Type":         {"text/plain"},
+				},
+				Body: []byte("secret"),
+			},
+			RespInfo: TraceResponseInfo{
+				Headers: http.Header{"Location": {"https://minio:9000/bucket/secret/object.txt"}},
+				Body:    []byte("secret"),
+			},
+		},
+	}
+
+	got := trace.Anonymize()
+	if !strings.HasPrefix(got.Path, "/bucket/") || strings.Contains(got.Path, "secret") {
+		t.Errorf("expected object name to be hashed, got %q", got.Path)
+	}
+	if got.HTTP.ReqInfo.Path != got.Path {
+		t.Errorf("expected request path %q, got %q", got.Path, got.HTTP.ReqInfo.Path)
+	}
+	if q := got.HTTP.ReqInfo.RawQuery; !strings.Contains(q, "list-type=2") || strings.Contains(q, "secret") {
+		t.Errorf("expected prefix to be hashed, got %q", q)
+	}
+	if got.HTTP.ReqInfo.Client != "192.168.1.0:51234" {
+		t.Errorf("expected client address to be masked, got %q", got.HTTP.ReqInfo.Client)
+	}
+	if got.HTTP.ReqInfo.Body != nil || got.HTTP.RespInfo.Body != nil {
+		t.Error("expected bodies to be removed")
+	}
+	reqHdr := got.HTTP.ReqInfo.Headers
+	if reqHdr.Get("Authorization") != "" || reqHdr.Get("X-Amz-Security-Token") != "" {
+		t.Errorf("expected credentials to be removed, got %v", reqHdr)
+	}
+	if v := reqHdr.Get("X-Forwarded-For"); v != "203.0.113.0, 10.1.2.0" {
+		t.Errorf("expected forwarded addresses to be masked, got %q", v)
+	}
+	if v := reqHdr.Get("X-Real-Ip"); v != "203.0.113.0" {
+		t.Errorf("expected real IP to be masked, got %q", v)
+	}
+	if v := reqHdr.Get("X-Amz-Copy-Source"); !strings.HasPrefix(v, "/bucket/") || strings.Contains(v, "secret") {
+		t.Errorf("expected copy source to be hashed, got %q", v)
+	}
+	if v := reqHdr.Get("X-Amz-Meta-Owner"); v == "" || v == "secret" {
+		t.Errorf("expected user metadata to be hashed, got %q", v)
+	}
+	if v := reqHdr.Get("Content-Type"); v != "text/plain" {
+		t.Errorf("expected other headers to be kept, got %q", v)
+	}
+	if v := got.HTTP.RespInfo.Headers.Get("Location"); !strings.HasPrefix(v, "https://minio:9000/bucket/") || strings.Contains(v, "secret") {
+		t.Errorf("expected location to be hashed, got %q", v)
+	}
+	if trace.HTTP.ReqInfo.Headers.Get("Authorization") == "" || trace.HTTP.ReqInfo.Headers.Get("X-Real-Ip") != "203.0.113.7" {
+		t.Error("expected the original headers to be unchanged")
+	}
+	if trace.HTTP.ReqInfo.Client != "192.168.1.42:51234" || trace.Path != "/bucket/secret/object.txt" {
+		t.Error("expected the original trace to be unchanged")
+	}
+	if again := trace.Anonymize(); again.Path != got.Path {
+		t.Errorf("expected stable hashes, got %q and %q", got.Path, again.Path)
+	}
+
+	for addr, want := range map[string]string{
+		"10.0.0.7":                   "10.0.0.0",
+		"[2001:db8::1:2:3:4]:9000":   "[2001:db8::]:9000",
+		"not-an-ip":                  "not-an-ip",
+		"":                           "",
+		"[2001:db8:1:2::42]:443":     "[2001:db8:1:2::]:443",
+		"203.0.113.200:443":          "203.0.113.0:443",
+		"::ffff:198.51.100.99":       "198.51.100.0",
+		"[::ffff:198.51.100.99]:123": "198.51.100.0:123",
+	} {
+		if got := anonymizeAddr(addr); got != want {
+			t.Errorf("anonymizeAddr(%q): expected %q, got %q", addr, want, got)
+		}
+	}
+}
+
+func TestServiceTraceAnonymize(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(TraceInfo{
+			TraceType: TraceS3,
+			Time:      time.Now(),
+			Path:      "/bucket/secret.txt",
+			HTTP: &TraceHTTPStats{
+				ReqInfo: TraceRequestInfo{Path: "/bucket/secret.txt", Client: "172.16.5.9:40000"},
+			},
+		})
+	})
+
+	for _, anonymize := range []bool{false, true} {
+		ctx, cancel := context.WithCancel(context.Background())
+		info := <-adm.ServiceTrace(ctx, ServiceTraceOpts{S3: true, Anonymize: anonymize})
+		cancel()
+		if info.Err != nil {
+			t.Fatal(info.Err)
+		}
+		redacted := !strings.Contains(info.Trace.Path, "secret") && info.Trace.HTTP.ReqInfo.Client == "172.16.5.0:40000"
+		if redacted != anonymize {
+			t.Errorf("anonymize=%v: unexpected trace path %q, client %q", anonymize, info.Trace.Path, info.Trace.HTTP.ReqInfo.Client)
+		}
+	}
+}
@@ -27,6 +27,9 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7/pkg/tags"
@@ -73,8 +76,12 @@ type AccountInfo struct {
 }
 
 // AccountOpts allows for configurable behavior with "prefix-usage"
+// and restricting the returned buckets to a bucket name prefix.
 type AccountOpts struct {
 	PrefixUsage bool
+	// Prefix only returns buckets whose name starts with it. Buckets
+	// are also filtered on the client, for servers that ignore it.
+	Prefix string
 }
 
 // AccountInfo returns the usage info for the authenticating account.
@@ -83,6 +90,9 @@ func (adm *AdminClient) AccountInfo(ctx context.Context, opts AccountOpts) (Acco
 	if opts.PrefixUsage {
 		q.Set("prefix-usage", "true")
 	}
+	if opts.Prefix != "" {
+		q.Set("prefix", opts.Prefix)
+	}
 	resp, err := adm.executeMethod(ctx, http.MethodGet,
 		requestData{
 			relPath:     adminAPIPrefix + "/accountinfo",
@@ -112,9 +122,23 @@ func (adm *AdminClient) AccountInfo(ctx context.Context, opts AccountOpts) (Acco
 		return AccountInfo{}, err
 	}
 
+	if opts.Prefix != "" {
+		accountInfo.Buckets = filterBucketsByPrefix(accountInfo.Buckets, opts.Prefix)
+	}
 	return accountInfo, nil
 }
 
+// filterBucketsByPrefix returns the buckets whose name starts with prefix.
+func filterBucketsByPrefix(buckets []BucketAccessInfo, prefix string) []BucketAccessInfo {
+	filtered := buckets[:0]
+	for _, b := range buckets {
+		if strings.HasPrefix(b.Name, prefix) {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered
+}
+
 // AccountStatus - account status.
 type AccountStatus string
 
@@ -182,10 +206,71 @@ func (adm *AdminClient) RemoveUser(ctx context.Context, accessKey string) error
 	return nil
 }
 
+// ListUsersOpts - options for ListUsersPaginated.
+type ListUsersOpts struct {
+	// Marker is the NextMarker of the previous page,
+	// empty to start with the first page.
+	Marker string
+	// Limit is the maximum number of users in a page,
+	// zero lets the server choose.
+	Limit int
+}
+
+// UsersPage - a page of users returned by ListUsersPaginated.
+type UsersPage struct {
+	Users map[string]UserInfo `json:"users"`
+	// NextMarker is the marker of the next page,
+	// empty if this is the last page.
+	NextMarker string `json:"nextMarker,omitempty"`
+}
+
 // ListUsers - list all users.
 func (adm *AdminClient) ListUsers(ctx context.Context) (map[string]UserInfo, error) {
+	users := make(map[string]UserInfo)
+	var opts ListUsersOpts
+	for {
+		page, err := adm.ListUsersPaginated(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for name, info := range page.Users {
+			users[name] = info
+		}
+		if page.NextMarker == "" || page.NextMarker == opts.Marker {
+			return users, nil
+		}
+		opts.Marker = page.NextMarker
+	}
+}
+
+const (
+	// UsersPaginatedHeader is the header set by servers that returned a
+	// page of users instead of the plain map of all users.
+	UsersPaginatedHeader = "x-minio-users-paginated"
+
+	// UsersPaginatedTrue is the value set in header if a page of users
+	// was returned.
+	UsersPaginatedTrue = "true"
+)
+
+// ListUsersPaginated - list a page of users.
+//
+// Pagination needs server support: the server must honour the marker and
+// limit parameters and set UsersPaginatedHeader, which no MinIO release
+// does at the time of writing. Servers without support return all users,
+// which are returned as a single page without NextMarker.
+func (adm *AdminClient) ListUsersPaginated(ctx context.Context, opts ListUsersOpts) (UsersPage, error) {
+	queryValues := url.Values{}
+	if opts.Marker != "" {
+		queryValues.Set("marker", opts.Marker)
+	}
+	if opts.Limit > 0 {
+		queryValues.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
 	reqData := requestData{
-		relPath: adminAPIPrefix + "/list-users",
+		relPath:     adminAPIPrefix + "/list-users",
+		queryValues: queryValues,
 	}
 
 	// Execute GET on /minio/admin/v3/list-users
@@ -193,24 +278,36 @@ func (adm *AdminClient) ListUsers(ctx context.Context) (map[string]UserInfo, err
 
 	defer closeResponse(resp)
 	if err != nil {
-		return nil, err
+		return UsersPage{}, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, httpRespToErrorResponse(resp)
+		return UsersPage{}, httpRespToErrorResponse(resp)
 	}
 
 	data, err := DecryptData(adm.getSecretKey(), resp.Body)
 	if err != nil {
-		return nil, err
+		return UsersPage{}, err
 	}
 
-	users := make(map[string]UserInfo)
-	if err = json.Unmarshal(data, &users); err != nil {
-		return nil, err
+	return parseUsersPage(data, resp.Header.Get(UsersPaginatedHeader) == UsersPaginatedTrue)
+}
+
+// parseUsersPage parses a page of users if paginated is set, otherwise
+// the plain map of users sent by servers without pagination support.
+func parseUsersPage(data []byte, paginated bool) (UsersPage, error) {
+	var page UsersPage
+	if paginated {
+		if err := json.Unmarshal(data, &page); err != nil {
+			return UsersPage{}, err
+		}
+		return page, nil
 	}
 
-	return users, nil
+	if err := json.Unmarshal(data, &page.Users); err != nil {
+		return UsersPage{}, err
+	}
+	return page, nil
 }
 
 // GetUserInfo - get info on a user
@@ -256,6 +353,9 @@ type AddOrUpdateUserReq struct {
 	SecretKey string        `json:"secretKey,omitempty"`
 	Policy    string        `json:"policy,omitempty"`
 	Status    AccountStatus `json:"status"`
+
+	// Time at which the user account expires.
+	Expiration *time.Time `json:"expiration,omitempty"`
 }
 
 // SetUserReq - update user secret key, account status or policies.
@@ -306,6 +406,20 @@ func (adm *AdminClient) AddUser(ctx context.Context, accessKey, secretKey string
 	return adm.SetUser(ctx, accessKey, secretKey, AccountEnabled)
 }
 
+// AddUserWithExpiry - adds a user whose account expires at the given time.
+// A zero expiry adds a user without expiration, like AddUser.
+// The expiration is only honored by servers that support user expiry.
+func (adm *AdminClient) AddUserWithExpiry(ctx context.Context, accessKey, secretKey string, expiry time.Time) error {
+	req := AddOrUpdateUserReq{
+		SecretKey: secretKey,
+		Status:    AccountEnabled,
+	}
+	if !expiry.IsZero() {
+		req.Expiration = &expiry
+	}
+	return adm.SetUserReq(ctx, accessKey, req)
+}
+
 // SetUserStatus - adds a status for a user.
 func (adm *AdminClient) SetUserStatus(ctx context.Context, accessKey string, status AccountStatus) error {
 	queryValues := url.Values{}
@@ -397,6 +511,10 @@ func (r *AddServiceAccountReq) Validate() error {
 	if err := validateSAExpiration(r.Expiration); err != nil {
 		return err
 	}
+
+	if err := ValidateInlinePolicy(r.Policy); err != nil {
+		return err
+	}
 	return validateSADescription(r.Description)
 }
 
@@ -498,6 +616,11 @@ type UpdateServiceAccountReq struct {
 	NewName        string          `json:"newName,omitempty"`
 	NewDescription string          `json:"newDescription,omitempty"`
 	NewExpiration  *time.Time      `json:"newExpiration,omitempty"`
+
+	// ClearExpiration makes the service account non-expiring. A nil
+	// NewExpiration leaves the expiration unchanged, so setting both
+	// NewExpiration and ClearExpiration is rejected by Validate.
+	ClearExpiration bool `json:"-"`
 }
 
 func (u *UpdateServiceAccountReq) Validate() error {
@@ -505,6 +628,10 @@ func (u *UpdateServiceAccountReq) Validate() error {
 		return err
 	}
 
+	if u.ClearExpiration && u.NewExpiration != nil {
+		return errors.New("either a new expiration or clearing the expiration can be given, not both")
+	}
+
 	if err := validateSAExpiration(u.NewExpiration); err != nil {
 		return err
 	}
@@ -516,6 +643,10 @@ func (adm *AdminClient) UpdateServiceAccount(ctx context.Context, accessKey stri
 	if err := opts.Validate(); err != nil {
 		return err
 	}
+	if opts.ClearExpiration {
+		// The server treats the sentinel time as no expiration.
+		opts.NewExpiration = &timeSentinel
+	}
 	data, err := json.Marshal(opts)
 	if err != nil {
 		return err
@@ -656,6 +787,40 @@ func (adm *AdminClient) ListAccessKeysBulk(ctx context.Context, users []string,
 	return listResp, nil
 }
 
+// ListAllServiceAccounts - lists the service accounts and temporary (STS)
+// accounts of all users, keyed by their parent user. This uses the bulk
+// access key listing API, so a single request is made to the server.
+func (adm *AdminClient) ListAllServiceAccounts(ctx context.Context) (map[string][]ServiceAccountInfo, error) {
+	keys, err := adm.ListAccessKeysBulk(ctx, nil, ListAccessKeysOpts{ListType: AccessKeyListAll, All: true})
+	if err != nil {
+		return nil, err
+	}
+	return groupAccessKeysByParent(keys), nil
+}
+
+// groupAccessKeysByParent groups the accounts of a bulk access key listing
+// by parent user, falling back to the listed user if no parent is set.
+func groupAccessKeysByParent(keys map[string]ListAccessKeysResp) map[string][]ServiceAccountInfo {
+	accounts := make(map[string][]ServiceAccountInfo, len(keys))
+	for user, resp := range keys {
+		for _, list := range [][]ServiceAccountInfo{resp.ServiceAccounts, resp.STSKeys} {
+			for _, acc := range list {
+				parent := acc.ParentUser
+				if parent == "" {
+					parent = user
+				}
+				accounts[parent] = append(accounts[parent], acc)
+			}
+		}
+	}
+	for _, list := range accounts {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].AccessKey < list[j].AccessKey
+		})
+	}
+	return accounts
+}
+
 // InfoServiceAccountResp is the response body of the info service account call
 type InfoServiceAccountResp struct {
 	ParentUser    string     `json:"parentUser"`
@@ -667,6 +832,16 @@ type InfoServiceAccountResp struct {
 	Expiration    *time.Time `json:"expiration,omitempty"`
 }
 
+// PolicyStatements parses the statements of the policy of the service account,
+// either its inline policy or, if ImpliedPolicy is set, that of its parent user.
+// No statements are returned if the response has no policy.
+func (r InfoServiceAccountResp) PolicyStatements() ([]PolicyStatement, error) {
+	if r.Policy == "" {
+		return nil, nil
+	}
+	return ParsePolicyStatements([]byte(r.Policy))
+}
+
 // InfoServiceAccount - returns the info of service account belonging to the specified user
 func (adm *AdminClient) InfoServiceAccount(ctx context.Context, accessKey string) (InfoServiceAccountResp, error) {
 	queryValues := url.Values{}
@@ -700,6 +875,78 @@ func (adm *AdminClient) InfoServiceAccount(ctx context.Context, accessKey string
 	return infoResp, nil
 }
 
+// SvcAcctExport is the exported metadata of a service account. It never
+// contains the secret key of the account.
+type SvcAcctExport struct {
+	AccessKey     string     `json:"accessKey"`
+	ParentUser    string     `json:"parentUser"`
+	Name          string     `json:"name,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	AccountStatus string     `json:"accountStatus"`
+	ImpliedPolicy bool       `json:"impliedPolicy"`
+	Policy        string     `json:"policy,omitempty"`
+	Expiration    *time.Time `json:"expiration,omitempty"`
+}
+
+// exportServiceAccountsWorkers is the number of service accounts
+// ExportServiceAccounts fetches concurrently.
+const exportServiceAccountsWorkers = 8
+
+// ExportServiceAccounts - exports the metadata of the service accounts of
+// user, or of all users if user is empty, e.g. for backup. The policy of
+// every account is fetched with up to eight concurrent requests. Secret
+// keys are never exported. The accounts are sorted by access key.
+func (adm *AdminClient) ExportServiceAccounts(ctx context.Context, user string) ([]SvcAcctExport, error) {
+	var accounts []ServiceAccountInfo
+	if user == "" {
+		keys, err := adm.ListAccessKeysBulk(ctx, nil, ListAccessKeysOpts{ListType: AccessKeyListSvcaccOnly, All: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, resp := range keys {
+			accounts = append(accounts, resp.ServiceAccounts...)
+		}
+	} else {
+		listResp, err := adm.ListServiceAccounts(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		accounts = listResp.Accounts
+	}
+
+	exports := make([]SvcAcctExport, len(accounts))
+	errs := make([]error, len(accounts))
+	runConcurrently(len(accounts), exportServiceAccountsWorkers, func(i int) {
+		if errs[i] = ctx.Err(); errs[i] != nil {
+			return
+		}
+		info, err := adm.InfoServiceAccount(ctx, accounts[i].AccessKey)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		exports[i] = SvcAcctExport{
+			AccessKey:     accounts[i].AccessKey,
+			ParentUser:    info.ParentUser,
+			Name:          info.Name,
+			Description:   info.Description,
+			AccountStatus: info.AccountStatus,
+			ImpliedPolicy: info.ImpliedPolicy,
+			Policy:        info.Policy,
+			Expiration:    info.Expiration,
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(exports, func(i, j int) bool {
+		return exports[i].AccessKey < exports[j].AccessKey
+	})
+	return exports, nil
+}
+
 // DeleteServiceAccount - delete a specified service account. The server will reject
 // the request if the service account does not belong to the user initiating the request
 func (adm *AdminClient) DeleteServiceAccount(ctx context.Context, serviceAccount string) error {
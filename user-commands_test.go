@@ -0,0 +1,408 @@
+//
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+//
+
+package madmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestAdminClient returns a client for a test server using h as handler.
+func newTestAdminClient(t *testing.T, h http.HandlerFunc) *AdminClient {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adm, err := New(u.Host, "minioadmin", "minioadmin", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return adm
+}
+
+func TestAddUserWithExpiry(t *testing.T) {
+	expiry := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		expiry time.Time
+		want   *time.Time
+	}{
+		{name: "with expiry", expiry: expiry, want: &expiry},
+		{name: "zero expiry", expiry: time.Time{}, want: nil},
+	}
+	for _, test := range tests {
+		var (
+			gotReq       AddOrUpdateUserReq
+			gotAccessKey string
+			gotBody      []byte
+		)
+		adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotAccessKey = r.URL.Query().Get("accessKey")
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Error(err)
+			}
+			gotBody, err = DecryptData("minioadmin", bytes.NewReader(body))
+			if err != nil {
+				t.Error(err)
+			}
+			if err := json.Unmarshal(gotBody, &gotReq); err != nil {
+				t.Error(err)
+			}
+		})
+
+		if err := adm.AddUserWithExpiry(context.Background(), "user", "password", test.expiry); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if gotAccessKey != "user" {
+			t.Errorf("%s: expected access key user, got %q", test.name, gotAccessKey)
+		}
+		if gotReq.SecretKey != "password" || gotReq.Status != AccountEnabled {
+			t.Errorf("%s: unexpected request %s", test.name, gotBody)
+		}
+		switch {
+		case test.want == nil && gotReq.Expiration != nil:
+			t.Errorf("%s: expected no expiration, got %v", test.name, gotReq.Expiration)
+		case test.want != nil && (gotReq.Expiration == nil || !gotReq.Expiration.Equal(*test.want)):
+			t.Errorf("%s: expected expiration %v, got %s", test.name, *test.want, gotBody)
+		}
+		if test.want == nil && bytes.Contains(gotBody, []byte("expiration")) {
+			t.Errorf("%s: body carries an expiration: %s", test.name, gotBody)
+		}
+	}
+}
+
+func TestListUsersPaginated(t *testing.T) {
+	pages := map[string]UsersPage{
+		"": {
+			Users: map[string]UserInfo{
+				"alice": {Status: AccountEnabled},
+				"bob":   {Status: AccountDisabled},
+			},
+			NextMarker: "bob",
+		},
+		"bob": {
+			Users: map[string]UserInfo{
+				"carol": {Status: AccountEnabled, PolicyName: "readwrite"},
+			},
+		},
+	}
+	var markers []string
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		marker := r.URL.Query().Get("marker")
+		markers = append(markers, marker)
+		if limit := r.URL.Query().Get("limit"); limit != "" && limit != "2" {
+			t.Errorf("unexpected limit %q", limit)
+		}
+		page, ok := pages[marker]
+		if !ok {
+			t.Errorf("unexpected marker %q", marker)
+		}
+		data, err := json.Marshal(page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		edata, err := EncryptData("minioadmin", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set(UsersPaginatedHeader, UsersPaginatedTrue)
+		w.Write(edata)
+	})
+
+	ctx := context.Background()
+	page, err := adm.ListUsersPaginated(ctx, ListUsersOpts{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Users) != 2 || page.NextMarker != "bob" {
+		t.Fatalf("unexpected first page %+v", page)
+	}
+	page, err = adm.ListUsersPaginated(ctx, ListUsersOpts{Marker: page.NextMarker, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Users) != 1 || page.NextMarker != "" {
+		t.Fatalf("unexpected second page %+v", page)
+	}
+
+	markers = nil
+	users, err := adm.ListUsers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 3 || users["carol"].PolicyName != "readwrite" {
+		t.Errorf("unexpected users %+v", users)
+	}
+	if want := []string{"", "bob"}; !reflect.DeepEqual(markers, want) {
+		t.Errorf("expected markers %q, got %q", want, markers)
+	}
+}
+
+func TestListUsersPaginatedLegacy(t *testing.T) {
+	// A server without pagination support ignores marker and limit and
+	// returns the plain map of all users, here including a user named
+	// like a page field.
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		edata, err := EncryptData("minioadmin", []byte(`{"alice":{"status":"enabled"},"users":{"status":""},"nextMarker":{"status":"disabled"}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(edata)
+	})
+
+	page, err := adm.ListUsersPaginated(context.Background(), ListUsersOpts{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Users) != 3 || page.Users["nextMarker"].Status != AccountDisabled || page.NextMarker != "" {
+		t.Errorf("unexpected page %+v", page)
+	}
+	users, err := adm.ListUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 3 {
+		t.Errorf("unexpected users %+v", users)
+	}
+}
+
+func TestListAllServiceAccounts(t *testing.T) {
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/minio/admin/v3/list-access-keys-bulk" || q.Get("all") != "true" || q.Get("listType") != AccessKeyListAll {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		data, err := json.Marshal(map[string]ListAccessKeysResp{
+			"alice": {
+				ServiceAccounts: []ServiceAccountInfo{
+					{ParentUser: "alice", AccessKey: "SVC2"},
+					{ParentUser: "alice", AccessKey: "SVC1"},
+				},
+				STSKeys: []ServiceAccountInfo{
+					{ParentUser: "alice", AccessKey: "STS1"},
+				},
+			},
+			"bob": {
+				STSKeys: []ServiceAccountInfo{
+					{AccessKey: "STS2"},
+				},
+			},
+			"carol": {},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		edata, err := EncryptData("minioadmin", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(edata)
+	})
+
+	accounts, err := adm.ListAllServiceAccounts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	accessKeys := make(map[string][]string)
+	for parent, list := range accounts {
+		for _, acc := range list {
+			accessKeys[parent] = append(accessKeys[parent], acc.AccessKey)
+		}
+	}
+	want := map[string][]string{
+		"alice": {"STS1", "SVC1", "SVC2"},
+		"bob":   {"STS2"},
+	}
+	if !reflect.DeepEqual(accessKeys, want) {
+		t.Errorf("expected %v, got %v", want, accessKeys)
+	}
+}
+
+func TestUpdateServiceAccountExpiration(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	var body map[string]interface{}
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		data, err := DecryptData("minioadmin", r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body = nil
+		if err = json.Unmarshal(data, &body); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	testCases := []struct {
+		name string
+		req  UpdateServiceAccountReq
+		want map[string]interface{}
+	}{
+		{
+			name: "set",
+			req:  UpdateServiceAccountReq{NewExpiration: &expiry},
+			want: map[string]interface{}{"newExpiration": expiry.Format(time.RFC3339)},
+		},
+		{
+			name: "clear",
+			req:  UpdateServiceAccountReq{ClearExpiration: true},
+			want: map[string]interface{}{"newExpiration": "1970-01-01T00:00:00Z"},
+		},
+		{
+			name: "untouched",
+			req:  UpdateServiceAccountReq{NewStatus: "off"},
+			want: map[string]interface{}{"newStatus": "off"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := adm.UpdateServiceAccount(context.Background(), "svc", tc.req); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(body, tc.want) {
+				t.Errorf("expected body %v, got %v", tc.want, body)
+			}
+		})
+	}
+
+	err := adm.UpdateServiceAccount(context.Background(), "svc", UpdateServiceAccountReq{
+		NewExpiration:   &expiry,
+		ClearExpiration: true,
+	})
+	if err == nil {
+		t.Error("expected an error when setting and clearing the expiration")
+	}
+}
+
+func TestAccountInfoPrefix(t *testing.T) {
+	var query url.Values
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		// Behave like a server that does not support bucket filtering.
+		json.NewEncoder(w).Encode(AccountInfo{
+			AccountName: "minioadmin",
+			Buckets: []BucketAccessInfo{
+				{Name: "logs-2023"},
+				{Name: "data"},
+				{Name: "logs-2024"},
+			},
+		})
+	})
+
+	ctx := context.Background()
+	info, err := adm.AccountInfo(ctx, AccountOpts{Prefix: "logs-"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := query.Get("prefix"); got != "logs-" {
+		t.Errorf("expected prefix query param %q, got %q", "logs-", got)
+	}
+	var names []string
+	for _, b := range info.Buckets {
+		names = append(names, b.Name)
+	}
+	if want := []string{"logs-2023", "logs-2024"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected buckets %q, got %q", want, names)
+	}
+
+	info, err = adm.AccountInfo(ctx, AccountOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := query["prefix"]; ok {
+		t.Error("unexpected prefix query param")
+	}
+	if len(info.Buckets) != 3 {
+		t.Errorf("expected all 3 buckets, got %d", len(info.Buckets))
+	}
+}
+
+func TestExportServiceAccounts(t *testing.T) {
+	const secret = "SECRETKEY123"
+	adm := newTestAdminClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var v interface{}
+		switch r.URL.Path {
+		case "/minio/admin/v3/list-service-accounts":
+			if user := r.URL.Query().Get("user"); user != "alice" {
+				t.Errorf("unexpected user %q", user)
+			}
+			v = map[string]interface{}{
+				"accounts": []map[string]interface{}{
+					{"parentUser": "alice", "accessKey": "SVC2", "secretKey": secret},
+					{"parentUser": "alice", "accessKey": "SVC1", "secretKey": secret},
+				},
+			}
+		case "/minio/admin/v3/info-service-account":
+			accessKey := r.URL.Query().Get("accessKey")
+			v = map[string]interface{}{
+				"parentUser":    "alice",
+				"accountStatus": "on",
+				"impliedPolicy": accessKey == "SVC2",
+				"policy":        `{"Version":"2012-10-17"}`,
+				"name":          accessKey + "-name",
+				"secretKey":     secret,
+			}
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		edata, err := EncryptData("minioadmin", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(edata)
+	})
+
+	exports, err := adm.ExportServiceAccounts(context.Background(), "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SvcAcctExport{
+		{AccessKey: "SVC1", ParentUser: "alice", Name: "SVC1-name", AccountStatus: "on", Policy: `{"Version":"2012-10-17"}`},
+		{AccessKey: "SVC2", ParentUser: "alice", Name: "SVC2-name", AccountStatus: "on", ImpliedPolicy: true, Policy: `{"Version":"2012-10-17"}`},
+	}
+	if !reflect.DeepEqual(exports, want) {
+		t.Errorf("expected %+v, got %+v", want, exports)
+	}
+	data, err := json.Marshal(exports)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte(secret)) || bytes.Contains(bytes.ToLower(data), []byte("secretkey")) {
+		t.Errorf("export contains secret key: %s", data)
+	}
+}
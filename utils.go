@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/minio/minio-go/v7/pkg/s3utils"
@@ -125,6 +126,28 @@ func closeResponse(resp *http.Response) {
 	}
 }
 
+// runConcurrently calls fn for every index in [0, n),
+// running at most workers calls at the same time.
+func runConcurrently(n, workers int, fn func(i int)) {
+	if workers <= 0 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
 // TimedAction contains a number of actions and their accumulated duration in nanoseconds.
 type TimedAction struct {
 	Count   uint64 `json:"count"`